@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/billing-engine/config"
+	"github.com/billing-engine/internal/clock"
 	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/scheduler"
 	"github.com/billing-engine/internal/service"
 	"github.com/spf13/viper"
 	gormtrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/gorm.io/gorm.v1"
@@ -61,24 +64,48 @@ func Boostrap() *config.AppConfig {
 
 	repo := initRepo(gormDB)
 
+	clk, simulation := initClock(cfg.Simulation)
+
 	service := service.NewService(
 		repo,
+		clk,
 	)
 
+	pollInterval, err := time.ParseDuration(cfg.Scheduler.PollInterval)
+	if err != nil {
+		pollInterval = 10 * time.Second
+	}
+
 	return &config.AppConfig{
-		Config:  cfg,
-		Service: service,
+		Config:     cfg,
+		Service:    service,
+		Scheduler:  scheduler.NewScheduler(repo.JobRun, repo.Lock, clk, pollInterval),
+		Clock:      clk,
+		Simulation: simulation,
 	}
 }
 
-func initRepo(gormDB *gorm.DB) *repository.Repository {
-	loanRepo := repository.NewLoanRepository(gormDB)
-	userRepo := repository.NewUserRepository(gormDB)
-	payLoanRepo := repository.NewPayLoanRepository(gormDB)
-
-	return &repository.Repository{
-		Loan:    loanRepo,
-		User:    userRepo,
-		PayLoan: payLoanRepo,
+// initClock return wall clock, or virtual clock when simulation enabled
+func initClock(cfg config.SimulationConfig) (clock.Clock, *clock.Simulated) {
+	if !cfg.Enabled {
+		return clock.System, nil
 	}
+
+	start := time.Now()
+	if cfg.Start != "" {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04:05", cfg.Start, time.Local)
+		if err != nil {
+			log.Fatalf("Invalid simulation start %s, %v", cfg.Start, err)
+		}
+		start = parsed
+	}
+
+	log.Println("simulation enabled, virtual clock start at", start.Format("2006-01-02 15:04:05"))
+	simulation := clock.NewSimulated(start)
+
+	return simulation, simulation
+}
+
+func initRepo(gormDB *gorm.DB) *repository.Repository {
+	return repository.NewRepository(gormDB)
 }
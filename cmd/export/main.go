@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/billing-engine/boostrap"
+	"github.com/billing-engine/internal/service"
+)
+
+// export dataset to file without running the api, example :
+// go run ./cmd/export -dataset payments -format xlsx -from 2024-01-01 -to 2024-01-31 -out payments.xlsx
+func main() {
+	dataset := flag.String("dataset", "", "dataset to export: loans, schedules, payments or aging")
+	format := flag.String("format", "csv", "file format: csv or xlsx")
+	columns := flag.String("columns", "", "comma separated columns, empty for every column")
+	from := flag.String("from", "", "start date in format 2006-01-02")
+	to := flag.String("to", "", "end date (inclusive) in format 2006-01-02, as of date of aging")
+	out := flag.String("out", "", "output file, empty for stdout")
+	flag.Parse()
+
+	query := service.ExportQuery{
+		Dataset: *dataset,
+		Format:  *format,
+	}
+	if *columns != "" {
+		query.Columns = strings.Split(*columns, ",")
+	}
+	if *from != "" {
+		parsed, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			log.Fatal("invalid from ", err)
+		}
+		query.From = parsed
+	}
+	if *to != "" {
+		parsed, err := time.Parse("2006-01-02", *to)
+		if err != nil {
+			log.Fatal("invalid to ", err)
+		}
+		query.To = parsed.AddDate(0, 0, 1)
+	}
+
+	appConfig := boostrap.Boostrap()
+	if err := appConfig.Service.ValidateExport(query); err != nil {
+		log.Fatal(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal("failed create output ", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := appConfig.Service.Export(context.Background(), query, w); err != nil {
+		log.Fatal("failed export ", err)
+	}
+}
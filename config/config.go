@@ -1,10 +1,16 @@
 package config
 
-import "github.com/billing-engine/internal/service"
+import (
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/scheduler"
+	"github.com/billing-engine/internal/service"
+)
 
 type Config struct {
-	App      App
-	Database DatabaseConfig
+	App        App
+	Database   DatabaseConfig
+	Scheduler  SchedulerConfig
+	Simulation SimulationConfig
 }
 
 type App struct {
@@ -23,7 +29,25 @@ type DatabaseConfig struct {
 	AdditionalParameters string
 }
 
+// SchedulerConfig set how often scheduler check due job and cron expression of every job by job name,
+// job not listed use its default cron expression
+type SchedulerConfig struct {
+	PollInterval string
+	Jobs         map[string]string
+}
+
+// SimulationConfig run app on virtual clock starting at Start ("2006-01-02 15:04:05", default now)
+// that only move when advanced through simulation api, never enable it outside QA
+type SimulationConfig struct {
+	Enabled bool
+	Start   string
+}
+
+// AppConfig hold dependency shared by controller, Simulation is nil when simulation disabled
 type AppConfig struct {
-	Config  *Config
-	Service service.ServiceInterface
+	Config     *Config
+	Service    service.ServiceInterface
+	Scheduler  *scheduler.Scheduler
+	Clock      clock.Clock
+	Simulation *clock.Simulated
 }
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+)
+
+// Calendar hold weekend and public holiday of one country to decide business day
+type Calendar struct {
+	Country  string
+	holidays map[string]bool
+}
+
+func NewCalendar(country string, holidays []time.Time) *Calendar {
+	calendar := &Calendar{
+		Country:  country,
+		holidays: map[string]bool{},
+	}
+
+	for _, holiday := range holidays {
+		calendar.holidays[holiday.Format("2006-01-02")] = true
+	}
+
+	return calendar
+}
+
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	return !c.holidays[t.Format("2006-01-02")]
+}
+
+// Roll move date that fall on non business day based on convention, time of day is kept
+func (c *Calendar) Roll(t time.Time, convention int) time.Time {
+	switch convention {
+	case commons.RollConventionFollowing:
+		return c.following(t)
+	case commons.RollConventionModifiedFollowing:
+		// following, unless it cross to next month then preceding
+		rolled := c.following(t)
+		if rolled.Month() != t.Month() {
+			return c.preceding(t)
+		}
+		return rolled
+	case commons.RollConventionPreceding:
+		return c.preceding(t)
+	}
+
+	return t
+}
+
+func (c *Calendar) following(t time.Time) time.Time {
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t
+}
+
+func (c *Calendar) preceding(t time.Time) time.Time {
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, -1)
+	}
+
+	return t
+}
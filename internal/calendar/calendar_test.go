@@ -0,0 +1,53 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_IsBusinessDay(t *testing.T) {
+	calendar := NewCalendar("ID", []time.Time{
+		time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.True(t, calendar.IsBusinessDay(time.Date(2024, 8, 15, 10, 0, 0, 0, time.UTC)))
+	assert.False(t, calendar.IsBusinessDay(time.Date(2024, 8, 16, 10, 0, 0, 0, time.UTC)))
+	assert.False(t, calendar.IsBusinessDay(time.Date(2024, 8, 17, 10, 0, 0, 0, time.UTC)))
+	assert.False(t, calendar.IsBusinessDay(time.Date(2024, 8, 18, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestCalendar_Roll(t *testing.T) {
+	calendar := NewCalendar("ID", []time.Time{
+		time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC),
+	})
+
+	// friday holiday followed by weekend
+	holiday := time.Date(2024, 8, 16, 10, 0, 0, 0, time.UTC)
+	// friday holiday at end of month
+	endOfMonth := time.Date(2024, 8, 30, 10, 0, 0, 0, time.UTC)
+	businessDay := time.Date(2024, 8, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, holiday, calendar.Roll(holiday, commons.RollConventionNone))
+	})
+
+	t.Run("following", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 8, 19, 10, 0, 0, 0, time.UTC), calendar.Roll(holiday, commons.RollConventionFollowing))
+		assert.Equal(t, time.Date(2024, 9, 2, 10, 0, 0, 0, time.UTC), calendar.Roll(endOfMonth, commons.RollConventionFollowing))
+		assert.Equal(t, businessDay, calendar.Roll(businessDay, commons.RollConventionFollowing))
+	})
+
+	t.Run("modified following", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 8, 19, 10, 0, 0, 0, time.UTC), calendar.Roll(holiday, commons.RollConventionModifiedFollowing))
+		assert.Equal(t, time.Date(2024, 8, 29, 10, 0, 0, 0, time.UTC), calendar.Roll(endOfMonth, commons.RollConventionModifiedFollowing))
+	})
+
+	t.Run("preceding", func(t *testing.T) {
+		assert.Equal(t, businessDay, calendar.Roll(holiday, commons.RollConventionPreceding))
+		assert.Equal(t, time.Date(2024, 8, 29, 10, 0, 0, 0, time.UTC), calendar.Roll(endOfMonth, commons.RollConventionPreceding))
+	})
+}
@@ -0,0 +1,69 @@
+package clock
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrBackward = errors.New("simulated time can not go backward")
+
+// Clock is source of current time, service and scheduler read time only from clock so it can be simulated
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// System is wall clock
+var System Clock = systemClock{}
+
+// Simulated is virtual clock that stand still until advanced, used to walk loan through weeks in seconds
+type Simulated struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+func NewSimulated(start time.Time) *Simulated {
+	return &Simulated{
+		now: start,
+	}
+}
+
+func (s *Simulated) Now() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.now
+}
+
+// Advance move virtual time forward by d and return new time
+func (s *Simulated) Advance(d time.Duration) (time.Time, error) {
+	if d < 0 {
+		return time.Time{}, ErrBackward
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = s.now.Add(d)
+
+	return s.now, nil
+}
+
+// Set move virtual time forward to t, time is never moved backward because run already recorded at later time
+func (s *Simulated) Set(t time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.Before(s.now) {
+		return time.Time{}, ErrBackward
+	}
+	s.now = t
+
+	return s.now, nil
+}
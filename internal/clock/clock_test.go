@@ -0,0 +1,38 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulated(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	clk := NewSimulated(start)
+
+	assert.Equal(t, start, clk.Now())
+
+	now, err := clk.Advance(7 * 24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), now)
+	assert.Equal(t, now, clk.Now())
+
+	_, err = clk.Advance(-time.Hour)
+	assert.ErrorIs(t, err, ErrBackward)
+
+	now, err = clk.Set(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), now)
+
+	_, err = clk.Set(start)
+	assert.ErrorIs(t, err, ErrBackward)
+	assert.Equal(t, now, clk.Now())
+}
+
+func TestSystem(t *testing.T) {
+	before := time.Now()
+	now := System.Now()
+
+	assert.False(t, now.Before(before))
+}
@@ -2,19 +2,21 @@ package commons
 
 import "time"
 
-// different time
+// different time between installment, weekly. Use simulation mode to walk loan through weeks quickly
 const (
-	DifferentTime = 30 * time.Second
+	DifferentTime = 7 * 24 * time.Hour
 )
 
-// interest
+// loan product used when create loan without product code
 const (
-	FlatInterest = 10
+	DefaultProductCode = "default"
 )
 
-// week pay
+// interest handling for grace period and payment holiday
 const (
-	CountWeekPay = 50
+	InterestHandlingWaived      = 0
+	InterestHandlingAccrued     = 1
+	InterestHandlingCapitalized = 2
 )
 
 // status user
@@ -27,12 +29,58 @@ const (
 
 // status loan
 const (
-	StatusLoanNew    = 0
-	StatusLoanClosed = 1
+	StatusLoanNew        = 0
+	StatusLoanClosed     = 1
+	StatusLoanChargedOff = 2
+	StatusLoanCancelled  = 3
 )
 
 // status payloan
 const (
-	StatusPayLoanUnpayed = 0
-	StatusPayLoanPayed   = 1
+	StatusPayLoanUnpayed    = 0
+	StatusPayLoanPayed      = 1
+	StatusPayLoanSuperseded = 2
+	StatusPayLoanWrittenOff = 3
+	StatusPayLoanVoid       = 4
+	StatusPayLoanRefinanced = 5
+)
+
+// status kyc user
+const (
+	StatusKycPending  = 0
+	StatusKycVerified = 1
+	StatusKycRejected = 2
+)
+
+// roll convention for due date that fall on weekend or holiday
+const (
+	RollConventionNone              = 0
+	RollConventionFollowing         = 1
+	RollConventionModifiedFollowing = 2
+	RollConventionPreceding         = 3
+)
+
+// origination fee charged to borrower when loan booked
+const (
+	OriginationFeeDeducted = 0 // deducted from disbursement
+	OriginationFeeFinanced = 1 // added to principal
+)
+
+// tax type of tax rule
+const (
+	TaxTypeVat         = 1 // charged on fee to borrower
+	TaxTypeWithholding = 2 // withheld from interest
+)
+
+// discount type of promo code
+const (
+	PromoDiscountPercentage   = 1 // percentage off interest of every installment
+	PromoDiscountInterestFree = 2 // interest free for first installments
+)
+
+// status job run of scheduler
+const (
+	StatusJobRunRunning = 0
+	StatusJobRunSuccess = 1
+	StatusJobRunFailed  = 2
 )
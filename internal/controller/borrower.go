@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CreateBorrowerRequest struct {
+	Username    string `json:"username" validate:"required,username"`
+	FullName    string `json:"full_name" validate:"required,max=100"`
+	NationalId  string `json:"national_id" validate:"required,max=32"`
+	Phone       string `json:"phone" validate:"max=20"`
+	Email       string `json:"email" validate:"max=100"`
+	Address     string `json:"address" validate:"max=255"`
+	DateOfBirth string `json:"date_of_birth" validate:"required"`
+}
+
+type UpdateBorrowerRequest struct {
+	Username    string `json:"username" validate:"required,username"`
+	FullName    string `json:"full_name" validate:"required,max=100"`
+	NationalId  string `json:"national_id" validate:"required,max=32"`
+	Phone       string `json:"phone" validate:"max=20"`
+	Email       string `json:"email" validate:"max=100"`
+	Address     string `json:"address" validate:"max=255"`
+	DateOfBirth string `json:"date_of_birth" validate:"required"`
+}
+
+type ReviewKycRequest struct {
+	Username  string `json:"username" validate:"required,username"`
+	KycStatus int    `json:"kyc_status"`
+}
+
+type GetBorrowerRequest struct {
+	Username string `json:"username" query:"username" params:"username" validate:"required,username"`
+}
+
+type BorrowerResponse struct {
+	Username       string `json:"username"`
+	FullName       string `json:"full_name"`
+	NationalId     string `json:"national_id"`
+	Phone          string `json:"phone"`
+	Email          string `json:"email"`
+	Address        string `json:"address"`
+	DateOfBirth    string `json:"date_of_birth"`
+	KycStatus      int    `json:"kyc_status"`
+	KycSubmittedAt string `json:"kyc_submitted_at"`
+	KycVerifiedAt  string `json:"kyc_verified_at"`
+	Status         int    `json:"status"`
+}
+
+func (ctrl *Controller) CreateBorrower(c *fiber.Ctx) error {
+	input := new(CreateBorrowerRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	dateOfBirth, err := time.Parse("2006-01-02", input.DateOfBirth)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"is_error":   true,
+			"message":    "date_of_birth must be in format YYYY-MM-DD",
+			"error_code": ErrorCodeInvalidRequest,
+		})
+	}
+
+	user, err := ctrl.AppConfig.Service.CreateBorrower(context.Background(), service.CreateBorrowerEntity{
+		Username:    input.Username,
+		FullName:    input.FullName,
+		NationalId:  input.NationalId,
+		Phone:       input.Phone,
+		Email:       input.Email,
+		Address:     input.Address,
+		DateOfBirth: dateOfBirth,
+	})
+	if err != nil {
+		return errorResponse(c, "failed create borrower", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toBorrowerResponse(user),
+		"message":  "successfully created",
+	})
+}
+
+func (ctrl *Controller) UpdateBorrower(c *fiber.Ctx) error {
+	input := new(UpdateBorrowerRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	dateOfBirth, err := time.Parse("2006-01-02", input.DateOfBirth)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"is_error":   true,
+			"message":    "date_of_birth must be in format YYYY-MM-DD",
+			"error_code": ErrorCodeInvalidRequest,
+		})
+	}
+
+	user, err := ctrl.AppConfig.Service.UpdateBorrower(context.Background(), service.UpdateBorrowerEntity{
+		Username:    input.Username,
+		FullName:    input.FullName,
+		NationalId:  input.NationalId,
+		Phone:       input.Phone,
+		Email:       input.Email,
+		Address:     input.Address,
+		DateOfBirth: dateOfBirth,
+	})
+	if err != nil {
+		return errorResponse(c, "failed update borrower", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toBorrowerResponse(user),
+		"message":  "successfully updated",
+	})
+}
+
+// ReviewKyc serve back office kyc review, not for borrower
+func (ctrl *Controller) ReviewKyc(c *fiber.Ctx) error {
+	input := new(ReviewKycRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	user, err := ctrl.AppConfig.Service.ReviewKyc(context.Background(), service.ReviewKycEntity{
+		Username:  input.Username,
+		KycStatus: input.KycStatus,
+	})
+	if err != nil {
+		return errorResponse(c, "failed review kyc", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toBorrowerResponse(user),
+		"message":  "kyc reviewed",
+	})
+}
+
+// GetBorrower serve v1 get-borrower and v2 borrowers/:username
+func (ctrl *Controller) GetBorrower(c *fiber.Ctx) error {
+	input := new(GetBorrowerRequest)
+
+	if err := bindReadRequest(c, input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	user, err := ctrl.AppConfig.Service.GetBorrower(context.Background(), input.Username)
+	if err != nil {
+		return errorResponse(c, "failed get borrower", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toBorrowerResponse(user),
+		"message":  "borrower data",
+	})
+}
+
+func toBorrowerResponse(user entity.UserEntity) BorrowerResponse {
+	return BorrowerResponse{
+		Username:       user.Username,
+		FullName:       user.FullName,
+		NationalId:     user.NationalId,
+		Phone:          user.Phone,
+		Email:          user.Email,
+		Address:        user.Address,
+		DateOfBirth:    formatTime("2006-01-02", user.DateOfBirth),
+		KycStatus:      user.KycStatus,
+		KycSubmittedAt: formatTime(time.RFC3339, user.KycSubmittedAt),
+		KycVerifiedAt:  formatTime(time.RFC3339, user.KycVerifiedAt),
+		Status:         user.Status,
+	}
+}
+
+func formatTime(layout string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(layout)
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CancelLoanRequest struct {
+	Username string `json:"username" validate:"required,username"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+type CancelLoanResponse struct {
+	LoanId       int    `json:"loan_id"`
+	ClosedAt     string `json:"closed_at"`
+	ClosedReason string `json:"closed_reason"`
+}
+
+func (ctrl *Controller) CancelLoan(c *fiber.Ctx) error {
+	input := new(CancelLoanRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	loan, err := ctrl.AppConfig.Service.CancelLoan(context.Background(), service.CancelLoanEntity{
+		Username: input.Username,
+		Reason:   input.Reason,
+	})
+	if err != nil {
+		return errorResponse(c, "failed cancel loan", err)
+	}
+
+	response := CancelLoanResponse{
+		LoanId:       loan.Id,
+		ClosedAt:     formatTime(time.RFC3339, loan.ClosedAt),
+		ClosedReason: loan.ClosedReason,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully cancelled",
+	})
+}
@@ -2,9 +2,13 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/billing-engine/config"
 	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -19,77 +23,100 @@ func NewController(appConfig *config.AppConfig) *Controller {
 }
 
 type GetOunstandingRequest struct {
-	Username string `json:"username"`
+	Username string `json:"username" query:"username" params:"username" validate:"required,username"`
 }
 
 type IsDelinquentRequest struct {
-	Usernanme string `json:"username"`
+	Usernanme string `json:"username" query:"username" params:"username" validate:"required,username"`
 }
 
 type MakePaymentRequest struct {
-	Username string  `json:"username"`
-	Amount   float64 `json:"amount"`
+	Username string  `json:"username" validate:"required,username"`
+	Amount   float64 `json:"amount" validate:"required,gt=0,max=1000000000000,decimals=2"`
+	Currency string  `json:"currency" validate:"required,len=3"`
 }
 
 type CreateLoanRequest struct {
-	Username string  `json:"username"`
-	Amount   float64 `json:"amount"`
+	Username    string  `json:"username" validate:"required,username"`
+	Amount      float64 `json:"amount" validate:"required,gt=0,max=1000000000000,decimals=2"`
+	ProductCode string  `json:"product_code" validate:"max=32"`
+	PromoCode   string  `json:"promo_code" validate:"max=32"`
+}
+
+type PaymentResponse struct {
+	Accepted            bool    `json:"accepted"`
+	ReasonCode          string  `json:"reason_code,omitempty"`
+	InstallmentsSettled int     `json:"installments_settled"`
+	Outstanding         float64 `json:"outstanding"`
+	NextDueDate         string  `json:"next_due_date,omitempty"`
 }
 
 type GetOunstandingResponse struct {
-	Amount float64 `json:"amount"`
-	Status string  `json:"status"`
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+	Fee      float64 `json:"fee"`
+	Tax      float64 `json:"tax"`
+	Total    float64 `json:"total"`
+	Status   string  `json:"status"`
 }
 
+// GetOutstanding serve v1 get-outstanding and v2 borrowers/:username/outstanding
 func (ctrl *Controller) GetOutstanding(c *fiber.Ctx) error {
 	input := new(GetOunstandingRequest)
 
-	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed to parsing data",
-		})
+	if err := bindReadRequest(c, input); err != nil {
+		return badRequestResponse(c)
 	}
 
-	amount, err := ctrl.AppConfig.Service.GetOutStanding(context.Background(), input.Username)
+	errs, err := validator.Validate(input)
 	if err != nil {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed create loan",
-			"error":    err.Error(),
-		})
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	outstanding, err := ctrl.AppConfig.Service.GetOutStanding(context.Background(), input.Username)
+	if err != nil {
+		return errorResponse(c, "failed get outstanding", err)
 	}
 
 	response := GetOunstandingResponse{
-		Amount: amount,
-		Status: "still exist",
+		Currency: outstanding.Currency,
+		Amount:   outstanding.Amount,
+		Fee:      outstanding.Fee,
+		Tax:      outstanding.Tax,
+		Total:    outstanding.Total,
+		Status:   "still exist",
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"is_error": false,
 		"success":  "success",
 		"data":     response,
-		"message":  "successfully created",
+		"message":  "successfully get outstanding",
 	})
 }
 
+// IsDelinquent serve v1 is-delinquent and v2 borrowers/:username/delinquency
 func (ctrl *Controller) IsDelinquent(c *fiber.Ctx) error {
 	input := new(IsDelinquentRequest)
 
-	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed to parsing data",
-		})
+	if err := bindReadRequest(c, input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
 	}
 
 	IsDelinquent, err := ctrl.AppConfig.Service.IsDelinquent(context.Background(), input.Usernanme)
 	if err != nil {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed create loan",
-			"error":    err.Error(),
-		})
+		return errorResponse(c, "failed get delinquent status", err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -103,28 +130,51 @@ func (ctrl *Controller) MakePayment(c *fiber.Ctx) error {
 	input := new(MakePaymentRequest)
 
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed to parsing data",
-		})
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
 	}
 
-	message, err := ctrl.AppConfig.Service.MakePayment(context.Background(), service.MakePaymentEntity{
+	result, err := ctrl.AppConfig.Service.MakePayment(context.Background(), service.MakePaymentEntity{
 		Username: input.Username,
 		Amount:   input.Amount,
+		Currency: input.Currency,
 	})
 	if err != nil {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed make payment",
-			"error":    err.Error(),
+		return errorResponse(c, "failed make payment", err)
+	}
+
+	response := PaymentResponse{
+		Accepted:            result.Accepted,
+		ReasonCode:          result.ReasonCode,
+		InstallmentsSettled: result.InstallmentsSettled,
+		Outstanding:         result.Outstanding,
+	}
+	if !result.NextDueDate.IsZero() {
+		response.NextDueDate = result.NextDueDate.Format("2006-01-02")
+	}
+
+	// rejected payment is refused by business rule of loan
+	if !result.Accepted {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"is_error":   true,
+			"message":    result.Message,
+			"error_code": result.ReasonCode,
+			"data":       response,
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"is_error": false,
 		"success":  "success",
-		"message":  message,
+		"data":     response,
+		"message":  result.Message,
 	})
 }
 
@@ -132,22 +182,25 @@ func (ctrl *Controller) CreateLoan(c *fiber.Ctx) error {
 	input := new(CreateLoanRequest)
 
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed to parsing data",
-		})
+		return badRequestResponse(c)
 	}
 
-	err := ctrl.AppConfig.Service.CreateLoan(context.Background(), service.CreateLoanEntity{
-		Username: input.Username,
-		Amount:   input.Amount,
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	err = ctrl.AppConfig.Service.CreateLoan(context.Background(), service.CreateLoanEntity{
+		Username:    input.Username,
+		Amount:      input.Amount,
+		ProductCode: input.ProductCode,
+		PromoCode:   input.PromoCode,
 	})
 	if err != nil {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"is_error": true,
-			"message":  "failed create loan",
-			"error":    err.Error(),
-		})
+		return errorResponse(c, "failed create loan", err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -157,11 +210,24 @@ func (ctrl *Controller) CreateLoan(c *fiber.Ctx) error {
 	})
 }
 
-func (ctrl *Controller) ProcessScheduleTask() error {
-	err := ctrl.AppConfig.Service.ScheduleTask(context.Background())
+// ProcessScheduleTask check every open loan, scheduled time is not used because loan always checked against now.
+// Run is failed when any loan failed so it is visible in job runs, other loan is still processed
+func (ctrl *Controller) ProcessScheduleTask(ctx context.Context, scheduledAt time.Time) error {
+	summary, err := ctrl.AppConfig.Service.ScheduleTask(ctx)
+	log.Printf("schedule task processed %d closed %d delinquent %d written off %d failed %d",
+		summary.Processed, summary.Closed, summary.Delinquent, summary.WrittenOff, summary.Failed)
+
+	for _, failure := range summary.Failures {
+		log.Println("schedule task", failure.Err)
+	}
+
 	if err != nil {
 		return err
 	}
 
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d loan failed", summary.Failed, summary.Processed)
+	}
+
 	return nil
 }
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	ErrorCodeInvalidRequest = "INVALID_REQUEST"
+	ErrorCodeValidation     = "VALIDATION_FAILED"
+	ErrorCodeInternal       = "INTERNAL_ERROR"
+	ErrorCodeNotLeader      = "SCHEDULER_NOT_LEADER"
+)
+
+// errorStatus map kind of service error to http status and error code,
+// error not coming from service domain is treated as internal error
+func errorStatus(err error) (int, string) {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		return fiber.StatusInternalServerError, ErrorCodeInternal
+	}
+
+	switch serviceErr.Kind {
+	case service.ErrorKindNotFound:
+		return fiber.StatusNotFound, serviceErr.Code
+	case service.ErrorKindConflict:
+		return fiber.StatusConflict, serviceErr.Code
+	case service.ErrorKindValidation:
+		return fiber.StatusBadRequest, serviceErr.Code
+	case service.ErrorKindBusinessRule:
+		return fiber.StatusUnprocessableEntity, serviceErr.Code
+	}
+
+	return fiber.StatusInternalServerError, ErrorCodeInternal
+}
+
+// errorResponse write failed response in consistent envelope with status and error code based on err,
+// service error caused by one field of request is also listed on errors like failed validation
+func errorResponse(c *fiber.Ctx, message string, err error) error {
+	status, code := errorStatus(err)
+
+	response := fiber.Map{
+		"is_error":   true,
+		"message":    message,
+		"error_code": code,
+		"error":      err.Error(),
+	}
+
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) && serviceErr.Field != "" {
+		response["errors"] = []validator.FieldError{
+			{
+				Field:   serviceErr.Field,
+				Rule:    serviceErr.Code,
+				Message: serviceErr.Message,
+			},
+		}
+	}
+
+	return c.Status(status).JSON(response)
+}
+
+// badRequestResponse write failed response when request can not be parsed
+func badRequestResponse(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"is_error":   true,
+		"message":    "failed to parsing data",
+		"error_code": ErrorCodeInvalidRequest,
+	})
+}
+
+// validationResponse write failed response with every field that break its rule
+func validationResponse(c *fiber.Ctx, errs []validator.FieldError) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"is_error":   true,
+		"message":    "invalid request",
+		"error_code": ErrorCodeValidation,
+		"errors":     errs,
+	})
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "not found", err: service.ErrLoanNotFound, status: fiber.StatusNotFound, code: "LOAN_NOT_FOUND"},
+		{name: "conflict", err: service.ErrUserHasActiveLoan, status: fiber.StatusConflict, code: "USER_HAS_ACTIVE_LOAN"},
+		{name: "validation", err: service.ErrInvalidAmount, status: fiber.StatusBadRequest, code: "INVALID_AMOUNT"},
+		{name: "business rule", err: service.ErrKycNotVerified, status: fiber.StatusUnprocessableEntity, code: "KYC_NOT_VERIFIED"},
+		{name: "wrapped service error", err: fmt.Errorf("create loan: %w", service.ErrUserNotRegistered), status: fiber.StatusNotFound, code: "USER_NOT_REGISTERED"},
+		{name: "unknown error", err: errors.New("connection refused"), status: fiber.StatusInternalServerError, code: ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := errorStatus(tt.err)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestValidationResponse(t *testing.T) {
+	app := fiber.New()
+	ctrl := NewController(nil)
+	app.Post("/make-payment", ctrl.MakePayment)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/make-payment", strings.NewReader(`{"username": "", "amount": -100, "currency": "IDR"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	body := struct {
+		IsError   bool                   `json:"is_error"`
+		ErrorCode string                 `json:"error_code"`
+		Errors    []validator.FieldError `json:"errors"`
+	}{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	assert.True(t, body.IsError)
+	assert.Equal(t, ErrorCodeValidation, body.ErrorCode)
+	assert.Equal(t, []validator.FieldError{
+		{Field: "username", Rule: "required", Message: "username is required"},
+		{Field: "amount", Rule: "gt", Message: "amount must be greater than 0"},
+	}, body.Errors)
+}
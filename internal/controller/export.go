@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/billing-engine/internal/export"
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportRequest read dataset from path, format (default csv), comma separated columns
+// and from and to (inclusive) in format 2006-01-02 from query string
+type ExportRequest struct {
+	Dataset string `json:"dataset" params:"dataset" validate:"required"`
+	Format  string `json:"format" query:"format"`
+	Columns string `json:"columns" query:"columns" validate:"max=1000"`
+	From    string `json:"from" query:"from"`
+	To      string `json:"to" query:"to"`
+}
+
+// Export serve v2 exports/:dataset, file is streamed to client while rows read from database
+func (ctrl *Controller) Export(c *fiber.Ctx) error {
+	query, errs, err := bindExportRequest(c)
+	if err != nil {
+		return badRequestResponse(c)
+	}
+
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	if err := ctrl.AppConfig.Service.ValidateExport(query); err != nil {
+		return errorResponse(c, "failed export", err)
+	}
+
+	c.Set(fiber.HeaderContentType, export.ContentType(query.Format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, query.Dataset, query.Format))
+
+	// status and header already sent when rows written, error in the middle only can be logged
+	svc := ctrl.AppConfig.Service
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := svc.Export(context.Background(), query, w); err != nil {
+			log.Println("failed export", query.Dataset, err)
+		}
+	})
+
+	return nil
+}
+
+// bindExportRequest parse export request from path and query string, invalid field is returned on field errors
+func bindExportRequest(c *fiber.Ctx) (service.ExportQuery, []validator.FieldError, error) {
+	input := new(ExportRequest)
+
+	if err := c.ParamsParser(input); err != nil {
+		return service.ExportQuery{}, nil, err
+	}
+
+	if err := c.QueryParser(input); err != nil {
+		return service.ExportQuery{}, nil, err
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return service.ExportQuery{}, nil, err
+	}
+	if len(errs) > 0 {
+		return service.ExportQuery{}, errs, nil
+	}
+
+	from, to, errs := parseDateRange(input.From, input.To)
+	if len(errs) > 0 {
+		return service.ExportQuery{}, errs, nil
+	}
+
+	query := service.ExportQuery{
+		Dataset: input.Dataset,
+		Format:  strings.ToLower(input.Format),
+		From:    from,
+		To:      to,
+	}
+	if query.Format == "" {
+		query.Format = export.FormatCSV
+	}
+	if input.Columns != "" {
+		query.Columns = strings.Split(input.Columns, ",")
+	}
+
+	return query, nil, nil
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindExportRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		query  service.ExportQuery
+		errs   []validator.FieldError
+	}{
+		{
+			name:   "every filter",
+			target: "/exports/payments?format=XLSX&columns=loan_id,paid_amount&from=2024-01-01&to=2024-01-31",
+			query: service.ExportQuery{
+				Dataset: "payments",
+				Format:  "xlsx",
+				Columns: []string{"loan_id", "paid_amount"},
+				From:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "default format",
+			target: "/exports/loans",
+			query:  service.ExportQuery{Dataset: "loans", Format: "csv"},
+		},
+		{
+			name:   "invalid date",
+			target: "/exports/loans?to=31-01-2024",
+			errs:   []validator.FieldError{{Field: "to", Rule: "date", Message: "to must be in format YYYY-MM-DD"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/exports/:dataset", func(c *fiber.Ctx) error {
+				query, errs, err := bindExportRequest(c)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.errs, errs)
+				if len(errs) == 0 {
+					assert.Equal(t, tt.query, query)
+				}
+				return nil
+			})
+
+			_, err := app.Test(httptest.NewRequest("GET", tt.target, nil))
+			assert.NoError(t, err)
+		})
+	}
+}
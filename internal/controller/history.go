@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HistoryRequest read username from path and filter from query string,
+// from and to (inclusive) in format 2006-01-02 and status can be repeated
+type HistoryRequest struct {
+	Username string `json:"username" params:"username" validate:"required,username"`
+	Status   []int  `json:"status" query:"status"`
+	From     string `json:"from" query:"from"`
+	To       string `json:"to" query:"to"`
+	Cursor   string `json:"cursor" query:"cursor" validate:"max=200"`
+	Limit    int    `json:"limit" query:"limit" validate:"gte=1,max=100"`
+	Sort     string `json:"sort" query:"sort"`
+}
+
+type LoanHistoryResponse struct {
+	Loans      []LoanResponse `json:"loans"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
+
+type PaymentHistoryResponse struct {
+	Payments   []ScheduleInstallmentResponse `json:"payments"`
+	NextCursor string                        `json:"next_cursor,omitempty"`
+	HasMore    bool                          `json:"has_more"`
+}
+
+// ListLoans serve v2 borrowers/:username/loans
+func (ctrl *Controller) ListLoans(c *fiber.Ctx) error {
+	query, errs, err := bindHistoryRequest(c)
+	if err != nil {
+		return badRequestResponse(c)
+	}
+
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	history, err := ctrl.AppConfig.Service.ListLoans(context.Background(), query)
+	if err != nil {
+		return errorResponse(c, "failed get loan history", err)
+	}
+
+	response := LoanHistoryResponse{
+		Loans:      []LoanResponse{},
+		NextCursor: history.NextCursor,
+		HasMore:    history.NextCursor != "",
+	}
+	for _, loan := range history.Loans {
+		response.Loans = append(response.Loans, toLoanResponse(loan))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "loan history",
+	})
+}
+
+// ListPayments serve v2 borrowers/:username/payments
+func (ctrl *Controller) ListPayments(c *fiber.Ctx) error {
+	query, errs, err := bindHistoryRequest(c)
+	if err != nil {
+		return badRequestResponse(c)
+	}
+
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	history, err := ctrl.AppConfig.Service.ListPayments(context.Background(), query)
+	if err != nil {
+		return errorResponse(c, "failed get payment history", err)
+	}
+
+	response := PaymentHistoryResponse{
+		Payments:   []ScheduleInstallmentResponse{},
+		NextCursor: history.NextCursor,
+		HasMore:    history.NextCursor != "",
+	}
+	for _, payLoan := range history.Payments {
+		response.Payments = append(response.Payments, toScheduleInstallmentResponse(payLoan))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "payment history",
+	})
+}
+
+// bindHistoryRequest parse history request from path and query string, invalid field is returned on field errors
+func bindHistoryRequest(c *fiber.Ctx) (service.HistoryQuery, []validator.FieldError, error) {
+	input := new(HistoryRequest)
+
+	if err := c.ParamsParser(input); err != nil {
+		return service.HistoryQuery{}, nil, err
+	}
+
+	if err := c.QueryParser(input); err != nil {
+		return service.HistoryQuery{}, nil, err
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return service.HistoryQuery{}, nil, err
+	}
+	if len(errs) > 0 {
+		return service.HistoryQuery{}, errs, nil
+	}
+
+	query := service.HistoryQuery{
+		Username: input.Username,
+		Statuses: input.Status,
+		Cursor:   input.Cursor,
+		Limit:    input.Limit,
+		Sort:     input.Sort,
+	}
+
+	from, to, errs := parseDateRange(input.From, input.To)
+	if len(errs) > 0 {
+		return service.HistoryQuery{}, errs, nil
+	}
+	query.From, query.To = from, to
+
+	return query, nil, nil
+}
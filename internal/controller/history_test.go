@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindHistoryRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		query  service.HistoryQuery
+		errs   []validator.FieldError
+	}{
+		{
+			name:   "every filter",
+			target: "/borrowers/bambang/loans?status=0&status=1&from=2024-01-01&to=2024-01-31&cursor=abc&limit=10&sort=desc",
+			query: service.HistoryQuery{
+				Username: "bambang",
+				Statuses: []int{0, 1},
+				From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Cursor:   "abc",
+				Limit:    10,
+				Sort:     "desc",
+			},
+		},
+		{
+			name:   "no filter",
+			target: "/borrowers/bambang/loans",
+			query:  service.HistoryQuery{Username: "bambang"},
+		},
+		{
+			name:   "invalid limit",
+			target: "/borrowers/bambang/loans?limit=1000",
+			errs:   []validator.FieldError{{Field: "limit", Rule: "max", Message: "limit must be at most 100"}},
+		},
+		{
+			name:   "invalid date",
+			target: "/borrowers/bambang/loans?from=01-01-2024",
+			errs:   []validator.FieldError{{Field: "from", Rule: "date", Message: "from must be in format YYYY-MM-DD"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/borrowers/:username/loans", func(c *fiber.Ctx) error {
+				query, errs, err := bindHistoryRequest(c)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.errs, errs)
+				if len(errs) == 0 {
+					assert.Equal(t, tt.query, query)
+				}
+
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.target, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		})
+	}
+}
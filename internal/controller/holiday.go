@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentHolidayRequest struct {
+	Username         string `json:"username" validate:"required,username"`
+	Periods          int    `json:"periods" validate:"required,gt=0,max=52"`
+	InterestHandling int    `json:"interest_handling"`
+}
+
+type InstallmentResponse struct {
+	DueDate        string  `json:"due_date"`
+	Amount         float64 `json:"amount"`
+	FeeAmount      float64 `json:"fee_amount"`
+	TaxAmount      float64 `json:"tax_amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+	Status         int     `json:"status"`
+}
+
+func (ctrl *Controller) GrantPaymentHoliday(c *fiber.Ctx) error {
+	input := new(PaymentHolidayRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	payLoans, err := ctrl.AppConfig.Service.GrantPaymentHoliday(context.Background(), service.PaymentHolidayEntity{
+		Username:         input.Username,
+		Periods:          input.Periods,
+		InterestHandling: input.InterestHandling,
+	})
+	if err != nil {
+		return errorResponse(c, "failed grant payment holiday", err)
+	}
+
+	response := []InstallmentResponse{}
+	for _, payLoan := range payLoans {
+		response = append(response, InstallmentResponse{
+			DueDate:        payLoan.CreatedAt.Format(time.RFC3339),
+			Amount:         payLoan.Amount,
+			FeeAmount:      payLoan.FeeAmount,
+			TaxAmount:      payLoan.TaxAmount,
+			DiscountAmount: payLoan.DiscountAmount,
+			Status:         payLoan.Status,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully granted",
+	})
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/scheduler"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// name of scheduler job, also used as key of cron expression in config
+const (
+	JobScheduleTask     = "schedule_task"
+	JobReportSnapshot   = "report_snapshot"
+	JobMonthlyStatement = "monthly_statement"
+)
+
+type JobRunsRequest struct {
+	Name  string `json:"name" params:"name" validate:"required,max=64"`
+	Limit int    `json:"limit" query:"limit" validate:"gte=1,max=100"`
+}
+
+type JobRunResponse struct {
+	Id          int    `json:"id"`
+	JobName     string `json:"job_name"`
+	ScheduledAt string `json:"scheduled_at"`
+	StartedAt   string `json:"started_at"`
+	FinishedAt  string `json:"finished_at,omitempty"`
+	Status      int    `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// RegisterJobs register every scheduler job with cron expression from specs, job missing in specs use default.
+// Report snapshot catch up a month of missed day and schedule task only run once after downtime
+func (ctrl *Controller) RegisterJobs(s *scheduler.Scheduler, specs map[string]string) error {
+	jobs := []struct {
+		name    string
+		spec    string
+		catchUp int
+		run     scheduler.RunFunc
+	}{
+		{JobScheduleTask, "*/30 * * * * *", 1, ctrl.ProcessScheduleTask},
+		{JobReportSnapshot, "5 0 * * *", 31, ctrl.ProcessReportSnapshot},
+		{JobMonthlyStatement, "30 0 1 * *", 3, ctrl.ProcessMonthlyStatements},
+	}
+
+	for _, job := range jobs {
+		spec := job.spec
+		if specs[job.name] != "" {
+			spec = specs[job.name]
+		}
+
+		if err := s.Register(job.name, spec, job.catchUp, job.run); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetJobRuns serve v2 jobs/:name/runs, latest run first
+func (ctrl *Controller) GetJobRuns(c *fiber.Ctx) error {
+	input := &JobRunsRequest{Limit: 20}
+
+	if err := c.ParamsParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	if err := c.QueryParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	runs, err := ctrl.AppConfig.Scheduler.Runs(context.Background(), input.Name, input.Limit)
+	if err != nil {
+		return errorResponse(c, "failed get job runs", err)
+	}
+
+	response := []JobRunResponse{}
+	for _, run := range runs {
+		response = append(response, toJobRunResponse(run))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "job runs",
+	})
+}
+
+func toJobRunResponse(run entity.JobRunEntity) JobRunResponse {
+	response := JobRunResponse{
+		Id:          run.Id,
+		JobName:     run.JobName,
+		ScheduledAt: run.ScheduledAt.Format("2006-01-02 15:04:05"),
+		StartedAt:   run.StartedAt.Format("2006-01-02 15:04:05"),
+		Status:      run.Status,
+		Error:       run.Error,
+	}
+	if !run.FinishedAt.IsZero() {
+		response.FinishedAt = run.FinishedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
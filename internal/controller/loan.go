@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GetLoanRequest struct {
+	Id int `json:"id" params:"id" validate:"required,gt=0"`
+}
+
+type GetBorrowerScheduleRequest struct {
+	Username string `json:"username" params:"username" validate:"required,username"`
+}
+
+type LoanResponse struct {
+	Id              int     `json:"id"`
+	Username        string  `json:"username"`
+	ProductCode     string  `json:"product_code"`
+	Currency        string  `json:"currency"`
+	Amount          float64 `json:"amount"`
+	DisbursedAmount float64 `json:"disbursed_amount"`
+	PromoCode       string  `json:"promo_code"`
+	DiscountAmount  float64 `json:"discount_amount"`
+	Status          int     `json:"status"`
+	CreatedAt       string  `json:"created_at"`
+	ClosedAt        string  `json:"closed_at,omitempty"`
+	ClosedReason    string  `json:"closed_reason,omitempty"`
+	RefinancedFrom  int     `json:"refinanced_from,omitempty"`
+}
+
+type ScheduleInstallmentResponse struct {
+	Id             int     `json:"id"`
+	LoanId         int     `json:"loan_id"`
+	DueDate        string  `json:"due_date"`
+	Amount         float64 `json:"amount"`
+	FeeAmount      float64 `json:"fee_amount"`
+	TaxAmount      float64 `json:"tax_amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+	AmountDue      float64 `json:"amount_due"`
+	Status         int     `json:"status"`
+	PaidAt         string  `json:"paid_at,omitempty"`
+	PaidAmount     float64 `json:"paid_amount"`
+}
+
+type ScheduleSummaryResponse struct {
+	PaidInstallments   int     `json:"paid_installments"`
+	UnpaidInstallments int     `json:"unpaid_installments"`
+	Outstanding        float64 `json:"outstanding"`
+	NextDueDate        string  `json:"next_due_date,omitempty"`
+	NextDueAmount      float64 `json:"next_due_amount"`
+}
+
+type LoanScheduleResponse struct {
+	Loan         LoanResponse                  `json:"loan"`
+	Installments []ScheduleInstallmentResponse `json:"installments"`
+	Summary      ScheduleSummaryResponse       `json:"summary"`
+}
+
+// GetLoan serve v2 loans/:id
+func (ctrl *Controller) GetLoan(c *fiber.Ctx) error {
+	input := new(GetLoanRequest)
+
+	if err := bindReadRequest(c, input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	loan, err := ctrl.AppConfig.Service.GetLoan(context.Background(), input.Id)
+	if err != nil {
+		return errorResponse(c, "failed get loan", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toLoanResponse(loan),
+		"message":  "loan data",
+	})
+}
+
+// GetLoanSchedule serve v2 loans/:id/schedule
+func (ctrl *Controller) GetLoanSchedule(c *fiber.Ctx) error {
+	input := new(GetLoanRequest)
+
+	if err := bindReadRequest(c, input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	schedule, err := ctrl.AppConfig.Service.GetLoanSchedule(context.Background(), input.Id)
+	if err != nil {
+		return errorResponse(c, "failed get loan schedule", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toLoanScheduleResponse(schedule),
+		"message":  "loan schedule",
+	})
+}
+
+// GetBorrowerSchedule serve v2 borrowers/:username/schedule for active loan of borrower
+func (ctrl *Controller) GetBorrowerSchedule(c *fiber.Ctx) error {
+	input := new(GetBorrowerScheduleRequest)
+
+	if err := bindReadRequest(c, input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	schedule, err := ctrl.AppConfig.Service.GetBorrowerSchedule(context.Background(), input.Username)
+	if err != nil {
+		return errorResponse(c, "failed get loan schedule", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toLoanScheduleResponse(schedule),
+		"message":  "loan schedule",
+	})
+}
+
+func toLoanResponse(loan entity.LoanEntity) LoanResponse {
+	response := LoanResponse{
+		Id:              loan.Id,
+		Username:        loan.Username,
+		ProductCode:     loan.ProductCode,
+		Currency:        loan.Currency,
+		Amount:          loan.Amount,
+		DisbursedAmount: loan.DisbursedAmount,
+		PromoCode:       loan.PromoCode,
+		DiscountAmount:  loan.DiscountAmount,
+		Status:          loan.Status,
+		CreatedAt:       loan.CreatedAt.Format("2006-01-02"),
+		ClosedReason:    loan.ClosedReason,
+		RefinancedFrom:  loan.RefinancedFrom,
+	}
+	if !loan.ClosedAt.IsZero() {
+		response.ClosedAt = loan.ClosedAt.Format("2006-01-02")
+	}
+
+	return response
+}
+
+func toScheduleInstallmentResponse(payLoan entity.PayLoanEntity) ScheduleInstallmentResponse {
+	installment := ScheduleInstallmentResponse{
+		Id:             payLoan.Id,
+		LoanId:         payLoan.LoanId,
+		DueDate:        payLoan.CreatedAt.Format("2006-01-02"),
+		Amount:         payLoan.Amount,
+		FeeAmount:      payLoan.FeeAmount,
+		TaxAmount:      payLoan.TaxAmount,
+		DiscountAmount: payLoan.DiscountAmount,
+		AmountDue:      payLoan.Amount + payLoan.FeeAmount + payLoan.TaxAmount,
+		Status:         payLoan.Status,
+		PaidAmount:     payLoan.PaidAmount,
+	}
+	if !payLoan.PaidAt.IsZero() {
+		installment.PaidAt = payLoan.PaidAt.Format("2006-01-02 15:04:05")
+	}
+
+	return installment
+}
+
+func toLoanScheduleResponse(schedule service.LoanScheduleEntity) LoanScheduleResponse {
+	installments := []ScheduleInstallmentResponse{}
+	for _, payLoan := range schedule.PayLoans {
+		installments = append(installments, toScheduleInstallmentResponse(payLoan))
+	}
+
+	summary := ScheduleSummaryResponse{
+		PaidInstallments:   schedule.PaidCount,
+		UnpaidInstallments: schedule.UnpaidCount,
+		Outstanding:        schedule.Outstanding.Total,
+		NextDueAmount:      schedule.NextDueAmount,
+	}
+	if !schedule.NextDueDate.IsZero() {
+		summary.NextDueDate = schedule.NextDueDate.Format("2006-01-02")
+	}
+
+	return LoanScheduleResponse{
+		Loan:         toLoanResponse(schedule.Loan),
+		Installments: installments,
+		Summary:      summary,
+	}
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PortfolioResponse struct {
+	Currency    string  `json:"currency"`
+	LoanCount   int64   `json:"loan_count"`
+	Amount      float64 `json:"amount"`
+	Outstanding float64 `json:"outstanding"`
+}
+
+func (ctrl *Controller) GetPortfolio(c *fiber.Ctx) error {
+	portfolios, err := ctrl.AppConfig.Service.GetPortfolio(context.Background())
+	if err != nil {
+		return errorResponse(c, "failed get portfolio", err)
+	}
+
+	response := []PortfolioResponse{}
+	for _, portfolio := range portfolios {
+		response = append(response, PortfolioResponse{
+			Currency:    portfolio.Currency,
+			LoanCount:   portfolio.LoanCount,
+			Amount:      portfolio.Amount,
+			Outstanding: portfolio.Outstanding,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully get portfolio",
+	})
+}
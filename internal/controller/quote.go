@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type QuoteLoanRequest struct {
+	Username    string  `json:"username" validate:"required,username"`
+	Amount      float64 `json:"amount" validate:"required,gt=0,max=1000000000000,decimals=2"`
+	ProductCode string  `json:"product_code" validate:"max=32"`
+	PromoCode   string  `json:"promo_code" validate:"max=32"`
+}
+
+type QuoteLoanResponse struct {
+	ProductCode       string                `json:"product_code"`
+	PromoCode         string                `json:"promo_code"`
+	Currency          string                `json:"currency"`
+	Amount            float64               `json:"amount"`
+	DiscountAmount    float64               `json:"discount_amount"`
+	OriginationFee    float64               `json:"origination_fee"`
+	OriginationFeeTax float64               `json:"origination_fee_tax"`
+	DisbursedAmount   float64               `json:"disbursed_amount"`
+	Installments      []InstallmentResponse `json:"installments"`
+}
+
+func (ctrl *Controller) QuoteLoan(c *fiber.Ctx) error {
+	input := new(QuoteLoanRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	quote, err := ctrl.AppConfig.Service.QuoteLoan(context.Background(), service.QuoteLoanEntity{
+		Username:    input.Username,
+		Amount:      input.Amount,
+		ProductCode: input.ProductCode,
+		PromoCode:   input.PromoCode,
+	})
+	if err != nil {
+		return errorResponse(c, "failed quote loan", err)
+	}
+
+	response := QuoteLoanResponse{
+		ProductCode:       quote.Loan.ProductCode,
+		PromoCode:         quote.Loan.PromoCode,
+		Currency:          quote.Loan.Currency,
+		Amount:            quote.Loan.Amount,
+		DiscountAmount:    quote.Loan.DiscountAmount,
+		OriginationFee:    quote.Loan.OriginationFee,
+		OriginationFeeTax: quote.Loan.OriginationFeeTax,
+		DisbursedAmount:   quote.Loan.DisbursedAmount,
+		Installments:      []InstallmentResponse{},
+	}
+	for _, payLoan := range quote.PayLoans {
+		response.Installments = append(response.Installments, InstallmentResponse{
+			DueDate:        payLoan.CreatedAt.Format(time.RFC3339),
+			Amount:         payLoan.Amount,
+			FeeAmount:      payLoan.FeeAmount,
+			TaxAmount:      payLoan.TaxAmount,
+			DiscountAmount: payLoan.DiscountAmount,
+			Status:         payLoan.Status,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully quoted",
+	})
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RefinanceLoanRequest struct {
+	Username    string  `json:"username" validate:"required,username"`
+	Amount      float64 `json:"amount" validate:"required,gt=0,max=1000000000000,decimals=2"`
+	ProductCode string  `json:"product_code" validate:"max=32"`
+}
+
+type RefinanceLoanResponse struct {
+	LoanId          int     `json:"loan_id"`
+	RefinancedFrom  int     `json:"refinanced_from"`
+	Amount          float64 `json:"amount"`
+	PayoffAmount    float64 `json:"payoff_amount"`
+	DisbursedAmount float64 `json:"disbursed_amount"`
+}
+
+func (ctrl *Controller) RefinanceLoan(c *fiber.Ctx) error {
+	input := new(RefinanceLoanRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	result, err := ctrl.AppConfig.Service.RefinanceLoan(context.Background(), service.RefinanceLoanEntity{
+		Username:    input.Username,
+		Amount:      input.Amount,
+		ProductCode: input.ProductCode,
+	})
+	if err != nil {
+		return errorResponse(c, "failed refinance loan", err)
+	}
+
+	response := RefinanceLoanResponse{
+		LoanId:          result.Loan.Id,
+		RefinancedFrom:  result.Loan.RefinancedFrom,
+		Amount:          result.Loan.Amount,
+		PayoffAmount:    result.PayoffAmount,
+		DisbursedAmount: result.DisbursedAmount,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully refinanced",
+	})
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PortfolioMetricsResponse struct {
+	Currency            string  `json:"currency"`
+	LoanCount           int     `json:"loan_count"`
+	Outstanding         float64 `json:"outstanding"`
+	Par30Amount         float64 `json:"par30_amount"`
+	Par90Amount         float64 `json:"par90_amount"`
+	Par30               float64 `json:"par30"`
+	Par90               float64 `json:"par90"`
+	DelinquentBorrowers int     `json:"delinquent_borrowers"`
+	CollectionCount     int     `json:"collection_count"`
+	Collections         float64 `json:"collections"`
+}
+
+type PortfolioReportResponse struct {
+	Date    string                     `json:"date"`
+	Source  string                     `json:"source"`
+	Metrics []PortfolioMetricsResponse `json:"metrics"`
+}
+
+// GetPortfolioReport report portfolio as of end of query param date in format 2006-01-02, default today
+func (ctrl *Controller) GetPortfolioReport(c *fiber.Ctx) error {
+	date := ctrl.AppConfig.Clock.Now()
+	if c.Query("date") != "" {
+		parsed, err := time.Parse("2006-01-02", c.Query("date"))
+		if err != nil {
+			return badRequestResponse(c)
+		}
+		date = parsed
+	}
+
+	report, err := ctrl.AppConfig.Service.GetPortfolioReport(context.Background(), date)
+	if err != nil {
+		return errorResponse(c, "failed get portfolio report", err)
+	}
+
+	response := PortfolioReportResponse{
+		Date:    report.Date.Format("2006-01-02"),
+		Source:  report.Source,
+		Metrics: []PortfolioMetricsResponse{},
+	}
+	for _, m := range report.Metrics {
+		response.Metrics = append(response.Metrics, PortfolioMetricsResponse{
+			Currency:            m.Currency,
+			LoanCount:           m.LoanCount,
+			Outstanding:         m.Outstanding,
+			Par30Amount:         m.Par30Amount,
+			Par90Amount:         m.Par90Amount,
+			Par30:               m.Par30,
+			Par90:               m.Par90,
+			DelinquentBorrowers: m.DelinquentBorrowers,
+			CollectionCount:     m.CollectionCount,
+			Collections:         m.Collections,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully get portfolio report",
+	})
+}
+
+// ProcessReportSnapshot snapshot portfolio report of day before scheduled time, that day is complete so the snapshot not change anymore
+func (ctrl *Controller) ProcessReportSnapshot(ctx context.Context, scheduledAt time.Time) error {
+	return ctrl.AppConfig.Service.SnapshotPortfolioReport(ctx, scheduledAt.AddDate(0, 0, -1))
+}
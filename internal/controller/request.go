@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// bindReadRequest fill request of read route from path param on v2 route.
+// v1 route read it from query string, json body is still accepted for old client
+func bindReadRequest(c *fiber.Ctx, input interface{}) error {
+	if len(c.Route().Params) > 0 {
+		return c.ParamsParser(input)
+	}
+
+	if len(c.Body()) == 0 {
+		return c.QueryParser(input)
+	}
+
+	return c.BodyParser(input)
+}
+
+// parseDateRange parse optional from and to in format 2006-01-02, to is inclusive so it is returned as start of next day
+func parseDateRange(fromInput string, toInput string) (time.Time, time.Time, []validator.FieldError) {
+	var from, to time.Time
+
+	if fromInput != "" {
+		parsed, err := time.Parse("2006-01-02", fromInput)
+		if err != nil {
+			return time.Time{}, time.Time{}, []validator.FieldError{
+				{Field: "from", Rule: "date", Message: "from must be in format YYYY-MM-DD"},
+			}
+		}
+		from = parsed
+	}
+
+	if toInput != "" {
+		parsed, err := time.Parse("2006-01-02", toInput)
+		if err != nil {
+			return time.Time{}, time.Time{}, []validator.FieldError{
+				{Field: "to", Rule: "date", Message: "to must be in format YYYY-MM-DD"},
+			}
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindReadRequest(t *testing.T) {
+	app := fiber.New()
+	handler := func(c *fiber.Ctx) error {
+		input := new(GetOunstandingRequest)
+		if err := bindReadRequest(c, input); err != nil {
+			return badRequestResponse(c)
+		}
+
+		return c.SendString(input.Username)
+	}
+	app.Get("/v1/get-outstanding", handler)
+	app.Get("/v2/borrowers/:username/outstanding", handler)
+	app.Get("/v2/loans/:id", func(c *fiber.Ctx) error {
+		input := new(GetLoanRequest)
+		if err := bindReadRequest(c, input); err != nil {
+			return badRequestResponse(c)
+		}
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		body     string
+		status   int
+		username string
+	}{
+		{name: "v2 path param", target: "/v2/borrowers/bambang/outstanding", status: fiber.StatusOK, username: "bambang"},
+		{name: "v1 query string", target: "/v1/get-outstanding?username=bambang", status: fiber.StatusOK, username: "bambang"},
+		{name: "v1 json body", target: "/v1/get-outstanding", body: `{"username": "bambang"}`, status: fiber.StatusOK, username: "bambang"},
+		{name: "invalid loan id", target: "/v2/loans/abc", status: fiber.StatusBadRequest},
+		{name: "valid loan id", target: "/v2/loans/123", status: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.username != "" {
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.username, string(body))
+			}
+		})
+	}
+}
+
+// TestRequestValidationRules run every request through validator, so broken validate tag fail here instead of on request
+func TestRequestValidationRules(t *testing.T) {
+	requests := []interface{}{
+		GetOunstandingRequest{},
+		IsDelinquentRequest{},
+		MakePaymentRequest{},
+		CreateLoanRequest{},
+		CreateBorrowerRequest{},
+		UpdateBorrowerRequest{},
+		ReviewKycRequest{},
+		GetBorrowerRequest{},
+		GetLoanRequest{},
+		GetBorrowerScheduleRequest{},
+		RestructureLoanRequest{},
+		PaymentHolidayRequest{},
+		WriteOffLoanRequest{},
+		RecoveryPaymentRequest{},
+		CancelLoanRequest{},
+		RefinanceLoanRequest{},
+		QuoteLoanRequest{},
+		HistoryRequest{},
+		ExportRequest{},
+		StatementRequest{},
+		JobRunsRequest{},
+		AdvanceClockRequest{},
+	}
+
+	for _, request := range requests {
+		t.Run(reflect.TypeOf(request).Name(), func(t *testing.T) {
+			_, err := validator.Validate(request)
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RestructureLoanRequest struct {
+	Username          string `json:"username" validate:"required,username"`
+	Tenor             int    `json:"tenor" validate:"required,gt=0,max=520"`
+	HolidayPeriods    int    `json:"holiday_periods" validate:"gte=0,max=52"`
+	CapitalizeArrears bool   `json:"capitalize_arrears"`
+}
+
+type RestructureLoanResponse struct {
+	LoanId            int     `json:"loan_id"`
+	Outstanding       float64 `json:"outstanding"`
+	Arrears           float64 `json:"arrears"`
+	Tenor             int     `json:"tenor"`
+	HolidayPeriods    int     `json:"holiday_periods"`
+	CapitalizeArrears bool    `json:"capitalize_arrears"`
+}
+
+func (ctrl *Controller) RestructureLoan(c *fiber.Ctx) error {
+	input := new(RestructureLoanRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	restructure, err := ctrl.AppConfig.Service.RestructureLoan(context.Background(), service.RestructureLoanEntity{
+		Username:          input.Username,
+		Tenor:             input.Tenor,
+		HolidayPeriods:    input.HolidayPeriods,
+		CapitalizeArrears: input.CapitalizeArrears,
+	})
+	if err != nil {
+		return errorResponse(c, "failed restructure loan", err)
+	}
+
+	response := RestructureLoanResponse{
+		LoanId:            restructure.LoanId,
+		Outstanding:       restructure.Outstanding,
+		Arrears:           restructure.Arrears,
+		Tenor:             restructure.Tenor,
+		HolidayPeriods:    restructure.HolidayPeriods,
+		CapitalizeArrears: restructure.CapitalizeArrears,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully restructured",
+	})
+}
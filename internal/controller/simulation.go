@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdvanceClockRequest move virtual clock forward by days plus duration (like "36h"),
+// or to time in format 2006-01-02 15:04:05
+type AdvanceClockRequest struct {
+	Days     int    `json:"days" validate:"gte=0,max=3660"`
+	Duration string `json:"duration"`
+	To       string `json:"to"`
+}
+
+type ClockResponse struct {
+	Now          string          `json:"now"`
+	ScheduleTask *JobRunResponse `json:"schedule_task,omitempty"`
+}
+
+// GetClock show current virtual time of simulation
+func (ctrl *Controller) GetClock(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     ClockResponse{Now: ctrl.AppConfig.Simulation.Now().Format("2006-01-02 15:04:05")},
+		"message":  "successfully get clock",
+	})
+}
+
+// AdvanceClock move virtual time forward and let scheduler run jobs due at new time right away,
+// so delinquency and closure of loan is visible in response without waiting scheduler poll.
+// Virtual clock live in this process only, instance that not hold the scheduler lease refuse to advance
+func (ctrl *Controller) AdvanceClock(c *fiber.Ctx) error {
+	input := new(AdvanceClockRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	target, errs := advanceTarget(input, ctrl.AppConfig.Simulation.Now())
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	now, err := ctrl.AppConfig.Simulation.Set(target)
+	if err != nil {
+		return validationResponse(c, []validator.FieldError{
+			{Field: "to", Rule: "after_now", Message: err.Error()},
+		})
+	}
+
+	if err := ctrl.AppConfig.Scheduler.RunNow(context.Background()); err != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"is_error":   true,
+			"message":    "failed run scheduler, simulation need single instance",
+			"error_code": ErrorCodeNotLeader,
+			"error":      err.Error(),
+		})
+	}
+
+	response := ClockResponse{Now: now.Format("2006-01-02 15:04:05")}
+
+	runs, err := ctrl.AppConfig.Scheduler.Runs(context.Background(), JobScheduleTask, 1)
+	if err != nil {
+		return errorResponse(c, "failed get schedule task run", err)
+	}
+	if len(runs) > 0 {
+		run := toJobRunResponse(runs[0])
+		response.ScheduleTask = &run
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully advance clock",
+	})
+}
+
+// advanceTarget return time the clock is moved to, either to or now plus days and duration
+func advanceTarget(input *AdvanceClockRequest, now time.Time) (time.Time, []validator.FieldError) {
+	if input.To != "" {
+		if input.Days != 0 || input.Duration != "" {
+			return time.Time{}, []validator.FieldError{
+				{Field: "to", Rule: "excluded_with", Message: "to can not be set together with days or duration"},
+			}
+		}
+
+		to, err := time.ParseInLocation("2006-01-02 15:04:05", input.To, now.Location())
+		if err != nil {
+			return time.Time{}, []validator.FieldError{
+				{Field: "to", Rule: "datetime", Message: "to must be in format YYYY-MM-DD HH:MM:SS"},
+			}
+		}
+
+		return to, nil
+	}
+
+	var duration time.Duration
+	if input.Duration != "" {
+		parsed, err := time.ParseDuration(input.Duration)
+		if err != nil || parsed < 0 {
+			return time.Time{}, []validator.FieldError{
+				{Field: "duration", Rule: "duration", Message: "duration must be positive like 36h or 90m"},
+			}
+		}
+		duration = parsed
+	}
+
+	if input.Days == 0 && duration == 0 {
+		return time.Time{}, []validator.FieldError{
+			{Field: "days", Rule: "required_without", Message: "days, duration or to must be set"},
+		}
+	}
+
+	return now.AddDate(0, 0, input.Days).Add(duration), nil
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvanceTarget(t *testing.T) {
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		input  AdvanceClockRequest
+		target time.Time
+		field  string
+	}{
+		{name: "days", input: AdvanceClockRequest{Days: 7}, target: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{name: "days and duration", input: AdvanceClockRequest{Days: 1, Duration: "90m"}, target: time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)},
+		{name: "to", input: AdvanceClockRequest{To: "2025-02-17 00:00:00"}, target: time.Date(2025, 2, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "nothing set", input: AdvanceClockRequest{}, field: "days"},
+		{name: "invalid duration", input: AdvanceClockRequest{Duration: "a week"}, field: "duration"},
+		{name: "negative duration", input: AdvanceClockRequest{Duration: "-1h"}, field: "duration"},
+		{name: "invalid to", input: AdvanceClockRequest{To: "2025-02-17"}, field: "to"},
+		{name: "to with days", input: AdvanceClockRequest{To: "2025-02-17 00:00:00", Days: 1}, field: "to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, errs := advanceTarget(&tt.input, now)
+
+			if tt.field != "" {
+				assert.Len(t, errs, 1)
+				assert.Equal(t, tt.field, errs[0].Field)
+				return
+			}
+
+			assert.Empty(t, errs)
+			assert.Equal(t, tt.target, target)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/statement"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StatementRequest read loan id from path, format (default html) and period from and to (inclusive)
+// in format 2006-01-02 from query string, period default to previous calendar month
+type StatementRequest struct {
+	Id     int    `json:"id" params:"id" validate:"required,gt=0"`
+	Format string `json:"format" query:"format"`
+	From   string `json:"from" query:"from"`
+	To     string `json:"to" query:"to"`
+}
+
+// GetStatement serve v2 loans/:id/statement, html is shown inline and pdf is downloaded
+func (ctrl *Controller) GetStatement(c *fiber.Ctx) error {
+	query, errs, err := bindStatementRequest(c, ctrl.AppConfig.Clock.Now())
+	if err != nil {
+		return badRequestResponse(c)
+	}
+
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	document, err := ctrl.AppConfig.Service.GetStatement(context.Background(), query)
+	if err != nil {
+		return errorResponse(c, "failed get statement", err)
+	}
+
+	disposition := "inline"
+	if document.Format == statement.FormatPDF {
+		disposition = "attachment"
+	}
+
+	c.Set(fiber.HeaderContentType, statement.ContentType(document.Format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`%s; filename="statement-%d-%s.%s"`, disposition, document.LoanId, document.From.Format("2006-01-02"), document.Format))
+
+	return c.Status(fiber.StatusOK).Send(document.Content)
+}
+
+// ProcessMonthlyStatements generate statement of month before scheduled time for every open loan
+func (ctrl *Controller) ProcessMonthlyStatements(ctx context.Context, scheduledAt time.Time) error {
+	previousMonth := time.Date(scheduledAt.Year(), scheduledAt.Month(), 1, 0, 0, 0, 0, scheduledAt.Location()).AddDate(0, 0, -1)
+	generated, err := ctrl.AppConfig.Service.GenerateMonthlyStatements(ctx, previousMonth)
+	if generated > 0 {
+		log.Println("monthly statement generated", generated)
+	}
+
+	return err
+}
+
+// bindStatementRequest parse statement request from path and query string, invalid field is returned on field errors
+func bindStatementRequest(c *fiber.Ctx, now time.Time) (service.StatementQuery, []validator.FieldError, error) {
+	input := new(StatementRequest)
+
+	if err := c.ParamsParser(input); err != nil {
+		return service.StatementQuery{}, nil, err
+	}
+
+	if err := c.QueryParser(input); err != nil {
+		return service.StatementQuery{}, nil, err
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return service.StatementQuery{}, nil, err
+	}
+	if len(errs) > 0 {
+		return service.StatementQuery{}, errs, nil
+	}
+
+	query := service.StatementQuery{
+		LoanId: input.Id,
+		Format: strings.ToLower(input.Format),
+	}
+	if query.Format == "" {
+		query.Format = statement.FormatHTML
+	}
+
+	if input.From == "" && input.To == "" {
+		query.To = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		query.From = query.To.AddDate(0, -1, 0)
+		return query, nil, nil
+	}
+
+	if input.From == "" || input.To == "" {
+		return service.StatementQuery{}, []validator.FieldError{
+			{Field: "from", Rule: "required_with", Message: "from and to must be set together"},
+		}, nil
+	}
+
+	from, to, errs := parseDateRange(input.From, input.To)
+	if len(errs) > 0 {
+		return service.StatementQuery{}, errs, nil
+	}
+	query.From, query.To = from, to
+
+	return query, nil, nil
+}
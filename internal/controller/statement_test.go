@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindStatementRequest(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		target string
+		query  service.StatementQuery
+		errs   []validator.FieldError
+	}{
+		{
+			name:   "period from query",
+			target: "/loans/10/statement?format=PDF&from=2024-01-01&to=2024-01-31",
+			query: service.StatementQuery{
+				LoanId: 10,
+				Format: "pdf",
+				From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "default previous month",
+			target: "/loans/10/statement",
+			query: service.StatementQuery{
+				LoanId: 10,
+				Format: "html",
+				From:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:   "only from",
+			target: "/loans/10/statement?from=2024-01-01",
+			errs:   []validator.FieldError{{Field: "from", Rule: "required_with", Message: "from and to must be set together"}},
+		},
+		{
+			name:   "invalid id",
+			target: "/loans/0/statement",
+			errs:   []validator.FieldError{{Field: "id", Rule: "required", Message: "id is required"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/loans/:id/statement", func(c *fiber.Ctx) error {
+				query, errs, err := bindStatementRequest(c, now)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.errs, errs)
+				if len(errs) == 0 {
+					assert.Equal(t, tt.query, query)
+				}
+				return nil
+			})
+
+			_, err := app.Test(httptest.NewRequest("GET", tt.target, nil))
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaxSummaryResponse struct {
+	Currency          string  `json:"currency"`
+	From              string  `json:"from"`
+	To                string  `json:"to"`
+	InstallmentVat    float64 `json:"installment_vat"`
+	OriginationFeeVat float64 `json:"origination_fee_vat"`
+	TotalVat          float64 `json:"total_vat"`
+	WithholdingTax    float64 `json:"withholding_tax"`
+}
+
+// GetTaxSummary report tax for date range from query param from and to (inclusive) in format 2006-01-02
+func (ctrl *Controller) GetTaxSummary(c *fiber.Ctx) error {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		return badRequestResponse(c)
+	}
+
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		return badRequestResponse(c)
+	}
+
+	summaries, err := ctrl.AppConfig.Service.GetTaxSummary(context.Background(), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return errorResponse(c, "failed get tax summary", err)
+	}
+
+	response := []TaxSummaryResponse{}
+	for _, summary := range summaries {
+		response = append(response, TaxSummaryResponse{
+			Currency:          summary.Currency,
+			From:              from.Format("2006-01-02"),
+			To:                to.Format("2006-01-02"),
+			InstallmentVat:    summary.InstallmentVat,
+			OriginationFeeVat: summary.OriginationFeeVat,
+			TotalVat:          summary.TotalVat,
+			WithholdingTax:    summary.WithholdingTax,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     response,
+		"message":  "successfully get tax summary",
+	})
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/service"
+	"github.com/billing-engine/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WriteOffLoanRequest struct {
+	Username string `json:"username" validate:"required,username"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+type RecoveryPaymentRequest struct {
+	Username string  `json:"username" validate:"required,username"`
+	Amount   float64 `json:"amount" validate:"required,gt=0,max=1000000000000,decimals=2"`
+	Currency string  `json:"currency" validate:"required,len=3"`
+}
+
+type WriteOffResponse struct {
+	LoanId              int     `json:"loan_id"`
+	WrittenOffPrincipal float64 `json:"written_off_principal"`
+	WrittenOffInterest  float64 `json:"written_off_interest"`
+	WrittenOffAt        string  `json:"written_off_at"`
+	WriteOffReason      string  `json:"write_off_reason"`
+	RecoveredAmount     float64 `json:"recovered_amount"`
+}
+
+func (ctrl *Controller) WriteOffLoan(c *fiber.Ctx) error {
+	input := new(WriteOffLoanRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	loan, err := ctrl.AppConfig.Service.WriteOffLoan(context.Background(), service.WriteOffLoanEntity{
+		Username: input.Username,
+		Reason:   input.Reason,
+	})
+	if err != nil {
+		return errorResponse(c, "failed write off loan", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toWriteOffResponse(loan),
+		"message":  "successfully written off",
+	})
+}
+
+func (ctrl *Controller) MakeRecoveryPayment(c *fiber.Ctx) error {
+	input := new(RecoveryPaymentRequest)
+
+	if err := c.BodyParser(input); err != nil {
+		return badRequestResponse(c)
+	}
+
+	errs, err := validator.Validate(input)
+	if err != nil {
+		return errorResponse(c, "invalid validation rule", err)
+	}
+	if len(errs) > 0 {
+		return validationResponse(c, errs)
+	}
+
+	loan, err := ctrl.AppConfig.Service.MakeRecoveryPayment(context.Background(), service.MakePaymentEntity{
+		Username: input.Username,
+		Amount:   input.Amount,
+		Currency: input.Currency,
+	})
+	if err != nil {
+		return errorResponse(c, "failed make recovery payment", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"is_error": false,
+		"success":  "success",
+		"data":     toWriteOffResponse(loan),
+		"message":  "success make recovery payment",
+	})
+}
+
+func toWriteOffResponse(loan entity.LoanEntity) WriteOffResponse {
+	return WriteOffResponse{
+		LoanId:              loan.Id,
+		WrittenOffPrincipal: loan.WrittenOffPrincipal,
+		WrittenOffInterest:  loan.WrittenOffInterest,
+		WrittenOffAt:        formatTime(time.RFC3339, loan.WrittenOffAt),
+		WriteOffReason:      loan.WriteOffReason,
+		RecoveredAmount:     loan.RecoveredAmount,
+	}
+}
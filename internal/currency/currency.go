@@ -0,0 +1,57 @@
+package currency
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// decimals is minor unit of currency based on ISO 4217, currency not listed use 2 decimals
+var decimals = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"USD": 2,
+	"EUR": 2,
+	"SGD": 2,
+	"MYR": 2,
+	"PHP": 2,
+}
+
+func Decimals(code string) int {
+	if d, ok := decimals[code]; ok {
+		return d
+	}
+
+	return 2
+}
+
+// Round amount half away from zero to minor unit of currency
+func Round(code string, amount float64) float64 {
+	p := math.Pow10(Decimals(code))
+	return math.Round(amount*p) / p
+}
+
+// Format amount with minor unit of currency and comma as thousand separator, example 1,234,567.50
+func Format(code string, amount float64) string {
+	text := strconv.FormatFloat(math.Abs(Round(code, amount)), 'f', Decimals(code), 64)
+
+	integer, fraction, _ := strings.Cut(text, ".")
+	var sb strings.Builder
+	if amount < 0 && Round(code, amount) != 0 {
+		sb.WriteString("-")
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteRune(digit)
+	}
+	if fraction != "" {
+		sb.WriteString(".")
+		sb.WriteString(fraction)
+	}
+
+	return sb.String()
+}
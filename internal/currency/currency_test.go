@@ -0,0 +1,32 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRound(t *testing.T) {
+	t.Run("currency without decimals", func(t *testing.T) {
+		assert.Equal(t, float64(1235), Round("IDR", 1234.5))
+		assert.Equal(t, float64(1234), Round("JPY", 1234.49))
+	})
+
+	t.Run("currency with two decimals", func(t *testing.T) {
+		assert.Equal(t, 12.35, Round("USD", 12.345))
+		assert.Equal(t, 12.34, Round("SGD", 12.3449))
+	})
+
+	t.Run("unknown currency use two decimals", func(t *testing.T) {
+		assert.Equal(t, 2, Decimals(""))
+		assert.Equal(t, 0.33, Round("XYZ", 1.0/3))
+	})
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "1,234,568", Format("IDR", 1234567.5))
+	assert.Equal(t, "1,234,567.50", Format("USD", 1234567.5))
+	assert.Equal(t, "999.00", Format("EUR", 999))
+	assert.Equal(t, "0", Format("IDR", 0))
+	assert.Equal(t, "-1,000.25", Format("SGD", -1000.25))
+}
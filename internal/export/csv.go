@@ -0,0 +1,34 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (cw *CSVWriter) WriteHeader(names []string) error {
+	return cw.w.Write(names)
+}
+
+func (cw *CSVWriter) WriteRow(values []any) error {
+	record := []string{}
+	for _, value := range values {
+		record = append(record, formatValue(value))
+	}
+
+	return cw.w.Write(record)
+}
+
+func (cw *CSVWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
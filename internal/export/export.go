@@ -0,0 +1,120 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// supported export format
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Writer write rows one by one to underlying writer so export never hold whole table in memory.
+// Value of row is string, int, float64 or time.Time, Close must be called to finish the file
+type Writer interface {
+	WriteHeader(names []string) error
+	WriteRow(values []any) error
+	Close() error
+}
+
+// NewWriter return writer of format, sheet is used as worksheet name of xlsx
+func NewWriter(format string, w io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w, sheet)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// ContentType return mime type of format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "application/octet-stream"
+}
+
+// Column is one exported column of row type T
+type Column[T any] struct {
+	Name  string
+	Value func(row T) any
+}
+
+// SelectColumns pick columns by name in requested order, every column is returned when names is empty
+func SelectColumns[T any](columns []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		return columns, nil
+	}
+
+	byName := map[string]Column[T]{}
+	for _, column := range columns {
+		byName[column.Name] = column
+	}
+
+	result := []Column[T]{}
+	for _, name := range names {
+		column, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %s", name)
+		}
+		result = append(result, column)
+	}
+
+	return result, nil
+}
+
+// Header return name of columns
+func Header[T any](columns []Column[T]) []string {
+	names := []string{}
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+
+	return names
+}
+
+// Row return value of columns for row
+func Row[T any](columns []Column[T], row T) []any {
+	values := []any{}
+	for _, column := range columns {
+		values = append(values, column.Value(row))
+	}
+
+	return values
+}
+
+// formatValue format value as text, zero time is written as empty
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04:05")
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRow struct {
+	Name   string
+	Amount float64
+	At     time.Time
+}
+
+var testColumns = []Column[testRow]{
+	{Name: "name", Value: func(row testRow) any { return row.Name }},
+	{Name: "amount", Value: func(row testRow) any { return row.Amount }},
+	{Name: "at", Value: func(row testRow) any { return row.At }},
+}
+
+func TestSelectColumns(t *testing.T) {
+	columns, err := SelectColumns(testColumns, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "amount", "at"}, Header(columns))
+
+	columns, err = SelectColumns(testColumns, []string{"amount", " name"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"amount", "name"}, Header(columns))
+
+	_, err = SelectColumns(testColumns, []string{"unknown"})
+	assert.EqualError(t, err, "unknown column unknown")
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf, "loans")
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteHeader(Header(testColumns)))
+	assert.NoError(t, w.WriteRow(Row(testColumns, testRow{Name: "a,b", Amount: 1100.5, At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)})))
+	assert.NoError(t, w.WriteRow(Row(testColumns, testRow{Name: "c", Amount: 10})))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "name,amount,at\n\"a,b\",1100.5,2024-01-02 03:04:05\nc,10,\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf, "loans")
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteHeader(Header(testColumns)))
+	assert.NoError(t, w.WriteRow(Row(testColumns, testRow{Name: "a<b", Amount: 1100.5})))
+	assert.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="loans"`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<row r="1"><c r="A1" t="inlineStr"><is><t>name</t></is></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="A2" t="inlineStr"><is><t>a&lt;b</t></is></c><c r="B2"><v>1100.5</v></c><c r="C2" t="inlineStr"><is><t></t></is></c></row>`)
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	_, err := NewWriter("pdf", io.Discard, "loans")
+	assert.Equal(t, ErrUnknownFormat, err)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "BA", columnName(52))
+}
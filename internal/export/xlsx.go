@@ -0,0 +1,129 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// XLSXWriter write single worksheet workbook, rows are streamed into the zip entry of the sheet
+// with inline string so no shared string table kept in memory
+type XLSXWriter struct {
+	zw  *zip.Writer
+	w   *bufio.Writer
+	row int
+}
+
+func NewXLSXWriter(w io.Writer, sheet string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escape(sheet))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	xw := &XLSXWriter{
+		zw: zw,
+		w:  bufio.NewWriter(f),
+	}
+	if _, err := xw.w.WriteString(xlsxSheetStart); err != nil {
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (xw *XLSXWriter) WriteHeader(names []string) error {
+	values := []any{}
+	for _, name := range names {
+		values = append(values, name)
+	}
+
+	return xw.WriteRow(values)
+}
+
+// WriteRow write int and float64 as number cell and other value as text cell
+func (xw *XLSXWriter) WriteRow(values []any) error {
+	xw.row++
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<row r="%d">`, xw.row)
+	for i, value := range values {
+		ref := fmt.Sprintf("%s%d", columnName(i), xw.row)
+		switch value.(type) {
+		case int, float64:
+			fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, formatValue(value))
+		default:
+			fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escape(formatValue(value)))
+		}
+	}
+	sb.WriteString(`</row>`)
+
+	_, err := xw.w.WriteString(sb.String())
+	return err
+}
+
+func (xw *XLSXWriter) Close() error {
+	if _, err := xw.w.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	if err := xw.w.Flush(); err != nil {
+		return err
+	}
+
+	return xw.zw.Close()
+}
+
+// columnName return spreadsheet column name of zero based index, 0 is A and 26 is AA
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+func escape(text string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(text))
+	return sb.String()
+}
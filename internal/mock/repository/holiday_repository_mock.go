@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/holiday_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIHolidayRepository is a mock of IHolidayRepository interface.
+type MockIHolidayRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHolidayRepositoryMockRecorder
+}
+
+// MockIHolidayRepositoryMockRecorder is the mock recorder for MockIHolidayRepository.
+type MockIHolidayRepositoryMockRecorder struct {
+	mock *MockIHolidayRepository
+}
+
+// NewMockIHolidayRepository creates a new mock instance.
+func NewMockIHolidayRepository(ctrl *gomock.Controller) *MockIHolidayRepository {
+	mock := &MockIHolidayRepository{ctrl: ctrl}
+	mock.recorder = &MockIHolidayRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHolidayRepository) EXPECT() *MockIHolidayRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByCountry mocks base method.
+func (m *MockIHolidayRepository) GetByCountry(ctx context.Context, country string) ([]entity.HolidayEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCountry", ctx, country)
+	ret0, _ := ret[0].([]entity.HolidayEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCountry indicates an expected call of GetByCountry.
+func (mr *MockIHolidayRepositoryMockRecorder) GetByCountry(ctx, country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCountry", reflect.TypeOf((*MockIHolidayRepository)(nil).GetByCountry), ctx, country)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/job_run_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIJobRunRepository is a mock of IJobRunRepository interface.
+type MockIJobRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIJobRunRepositoryMockRecorder
+}
+
+// MockIJobRunRepositoryMockRecorder is the mock recorder for MockIJobRunRepository.
+type MockIJobRunRepositoryMockRecorder struct {
+	mock *MockIJobRunRepository
+}
+
+// NewMockIJobRunRepository creates a new mock instance.
+func NewMockIJobRunRepository(ctrl *gomock.Controller) *MockIJobRunRepository {
+	mock := &MockIJobRunRepository{ctrl: ctrl}
+	mock.recorder = &MockIJobRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIJobRunRepository) EXPECT() *MockIJobRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Finish mocks base method.
+func (m *MockIJobRunRepository) Finish(ctx context.Context, id, status int, message string, finishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, id, status, message, finishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIJobRunRepositoryMockRecorder) Finish(ctx, id, status, message, finishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIJobRunRepository)(nil).Finish), ctx, id, status, message, finishedAt)
+}
+
+// GetLast mocks base method.
+func (m *MockIJobRunRepository) GetLast(ctx context.Context, jobName string) (entity.JobRunEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLast", ctx, jobName)
+	ret0, _ := ret[0].(entity.JobRunEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLast indicates an expected call of GetLast.
+func (mr *MockIJobRunRepositoryMockRecorder) GetLast(ctx, jobName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLast", reflect.TypeOf((*MockIJobRunRepository)(nil).GetLast), ctx, jobName)
+}
+
+// ListByJob mocks base method.
+func (m *MockIJobRunRepository) ListByJob(ctx context.Context, jobName string, limit int) ([]entity.JobRunEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByJob", ctx, jobName, limit)
+	ret0, _ := ret[0].([]entity.JobRunEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByJob indicates an expected call of ListByJob.
+func (mr *MockIJobRunRepositoryMockRecorder) ListByJob(ctx, jobName, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByJob", reflect.TypeOf((*MockIJobRunRepository)(nil).ListByJob), ctx, jobName, limit)
+}
+
+// Start mocks base method.
+func (m *MockIJobRunRepository) Start(ctx context.Context, data entity.JobRunEntity) (entity.JobRunEntity, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, data)
+	ret0, _ := ret[0].(entity.JobRunEntity)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIJobRunRepositoryMockRecorder) Start(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIJobRunRepository)(nil).Start), ctx, data)
+}
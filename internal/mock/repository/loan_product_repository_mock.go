@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/loan_product_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILoanProductRepository is a mock of ILoanProductRepository interface.
+type MockILoanProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoanProductRepositoryMockRecorder
+}
+
+// MockILoanProductRepositoryMockRecorder is the mock recorder for MockILoanProductRepository.
+type MockILoanProductRepositoryMockRecorder struct {
+	mock *MockILoanProductRepository
+}
+
+// NewMockILoanProductRepository creates a new mock instance.
+func NewMockILoanProductRepository(ctrl *gomock.Controller) *MockILoanProductRepository {
+	mock := &MockILoanProductRepository{ctrl: ctrl}
+	mock.recorder = &MockILoanProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoanProductRepository) EXPECT() *MockILoanProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockILoanProductRepository) Get(ctx context.Context, code string) (entity.LoanProductEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, code)
+	ret0, _ := ret[0].(entity.LoanProductEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockILoanProductRepositoryMockRecorder) Get(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockILoanProductRepository)(nil).Get), ctx, code)
+}
@@ -7,6 +7,7 @@ package mock_repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/billing-engine/internal/repository/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,50 @@ func (m *MockILoanRepository) EXPECT() *MockILoanRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddRecovery mocks base method.
+func (m *MockILoanRepository) AddRecovery(ctx context.Context, loanId int, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecovery", ctx, loanId, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecovery indicates an expected call of AddRecovery.
+func (mr *MockILoanRepositoryMockRecorder) AddRecovery(ctx, loanId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecovery", reflect.TypeOf((*MockILoanRepository)(nil).AddRecovery), ctx, loanId, amount)
+}
+
+// Close mocks base method.
+func (m *MockILoanRepository) Close(ctx context.Context, loanId int, data entity.LoanEntity) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, loanId, data)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockILoanRepositoryMockRecorder) Close(ctx, loanId, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockILoanRepository)(nil).Close), ctx, loanId, data)
+}
+
+// CountByUsernameAndStatus mocks base method.
+func (m *MockILoanRepository) CountByUsernameAndStatus(ctx context.Context, username string, status int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUsernameAndStatus", ctx, username, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUsernameAndStatus indicates an expected call of CountByUsernameAndStatus.
+func (mr *MockILoanRepositoryMockRecorder) CountByUsernameAndStatus(ctx, username, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUsernameAndStatus", reflect.TypeOf((*MockILoanRepository)(nil).CountByUsernameAndStatus), ctx, username, status)
+}
+
 // CreateLoan mocks base method.
 func (m *MockILoanRepository) CreateLoan(ctx context.Context, data entity.LoanEntity) (entity.LoanEntity, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +110,21 @@ func (mr *MockILoanRepositoryMockRecorder) Get(ctx, username, status interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockILoanRepository)(nil).Get), ctx, username, status)
 }
 
+// GetById mocks base method.
+func (m *MockILoanRepository) GetById(ctx context.Context, loanId int) (entity.LoanEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, loanId)
+	ret0, _ := ret[0].(entity.LoanEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockILoanRepositoryMockRecorder) GetById(ctx, loanId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockILoanRepository)(nil).GetById), ctx, loanId)
+}
+
 // GetByStatus mocks base method.
 func (m *MockILoanRepository) GetByStatus(ctx context.Context, status int) ([]entity.LoanEntity, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +140,94 @@ func (mr *MockILoanRepositoryMockRecorder) GetByStatus(ctx, status interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatus", reflect.TypeOf((*MockILoanRepository)(nil).GetByStatus), ctx, status)
 }
 
+// GetByStatusAfterId mocks base method.
+func (m *MockILoanRepository) GetByStatusAfterId(ctx context.Context, status, afterId, limit int) ([]entity.LoanEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStatusAfterId", ctx, status, afterId, limit)
+	ret0, _ := ret[0].([]entity.LoanEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStatusAfterId indicates an expected call of GetByStatusAfterId.
+func (mr *MockILoanRepositoryMockRecorder) GetByStatusAfterId(ctx, status, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatusAfterId", reflect.TypeOf((*MockILoanRepository)(nil).GetByStatusAfterId), ctx, status, afterId, limit)
+}
+
+// GetPortfolioByCurrency mocks base method.
+func (m *MockILoanRepository) GetPortfolioByCurrency(ctx context.Context, status int) ([]entity.PortfolioEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolioByCurrency", ctx, status)
+	ret0, _ := ret[0].([]entity.PortfolioEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolioByCurrency indicates an expected call of GetPortfolioByCurrency.
+func (mr *MockILoanRepositoryMockRecorder) GetPortfolioByCurrency(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolioByCurrency", reflect.TypeOf((*MockILoanRepository)(nil).GetPortfolioByCurrency), ctx, status)
+}
+
+// ListByUsername mocks base method.
+func (m *MockILoanRepository) ListByUsername(ctx context.Context, filter entity.HistoryFilter) ([]entity.LoanEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUsername", ctx, filter)
+	ret0, _ := ret[0].([]entity.LoanEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUsername indicates an expected call of ListByUsername.
+func (mr *MockILoanRepositoryMockRecorder) ListByUsername(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUsername", reflect.TypeOf((*MockILoanRepository)(nil).ListByUsername), ctx, filter)
+}
+
+// StreamLoans mocks base method.
+func (m *MockILoanRepository) StreamLoans(ctx context.Context, filter entity.ExportFilter, fn func(entity.LoanEntity) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamLoans", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamLoans indicates an expected call of StreamLoans.
+func (mr *MockILoanRepositoryMockRecorder) StreamLoans(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLoans", reflect.TypeOf((*MockILoanRepository)(nil).StreamLoans), ctx, filter, fn)
+}
+
+// SumOriginationFeeTax mocks base method.
+func (m *MockILoanRepository) SumOriginationFeeTax(ctx context.Context, from, to time.Time) ([]entity.TaxSummaryEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumOriginationFeeTax", ctx, from, to)
+	ret0, _ := ret[0].([]entity.TaxSummaryEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumOriginationFeeTax indicates an expected call of SumOriginationFeeTax.
+func (mr *MockILoanRepositoryMockRecorder) SumOriginationFeeTax(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumOriginationFeeTax", reflect.TypeOf((*MockILoanRepository)(nil).SumOriginationFeeTax), ctx, from, to)
+}
+
+// UpdateAmount mocks base method.
+func (m *MockILoanRepository) UpdateAmount(ctx context.Context, loanId int, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAmount", ctx, loanId, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAmount indicates an expected call of UpdateAmount.
+func (mr *MockILoanRepositoryMockRecorder) UpdateAmount(ctx, loanId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAmount", reflect.TypeOf((*MockILoanRepository)(nil).UpdateAmount), ctx, loanId, amount)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockILoanRepository) UpdateStatus(ctx context.Context, loanId, status int) error {
 	m.ctrl.T.Helper()
@@ -93,3 +241,18 @@ func (mr *MockILoanRepositoryMockRecorder) UpdateStatus(ctx, loanId, status inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockILoanRepository)(nil).UpdateStatus), ctx, loanId, status)
 }
+
+// WriteOff mocks base method.
+func (m *MockILoanRepository) WriteOff(ctx context.Context, loanId int, data entity.LoanEntity) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOff", ctx, loanId, data)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteOff indicates an expected call of WriteOff.
+func (mr *MockILoanRepositoryMockRecorder) WriteOff(ctx, loanId, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOff", reflect.TypeOf((*MockILoanRepository)(nil).WriteOff), ctx, loanId, data)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/loan_restructure_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILoanRestructureRepository is a mock of ILoanRestructureRepository interface.
+type MockILoanRestructureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoanRestructureRepositoryMockRecorder
+}
+
+// MockILoanRestructureRepositoryMockRecorder is the mock recorder for MockILoanRestructureRepository.
+type MockILoanRestructureRepositoryMockRecorder struct {
+	mock *MockILoanRestructureRepository
+}
+
+// NewMockILoanRestructureRepository creates a new mock instance.
+func NewMockILoanRestructureRepository(ctrl *gomock.Controller) *MockILoanRestructureRepository {
+	mock := &MockILoanRestructureRepository{ctrl: ctrl}
+	mock.recorder = &MockILoanRestructureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoanRestructureRepository) EXPECT() *MockILoanRestructureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockILoanRestructureRepository) Create(ctx context.Context, data entity.LoanRestructureEntity) (entity.LoanRestructureEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(entity.LoanRestructureEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockILoanRestructureRepositoryMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockILoanRestructureRepository)(nil).Create), ctx, data)
+}
+
+// GetByLoanId mocks base method.
+func (m *MockILoanRestructureRepository) GetByLoanId(ctx context.Context, loanId int) ([]entity.LoanRestructureEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLoanId", ctx, loanId)
+	ret0, _ := ret[0].([]entity.LoanRestructureEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByLoanId indicates an expected call of GetByLoanId.
+func (mr *MockILoanRestructureRepositoryMockRecorder) GetByLoanId(ctx, loanId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLoanId", reflect.TypeOf((*MockILoanRestructureRepository)(nil).GetByLoanId), ctx, loanId)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/lock_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILockRepository is a mock of ILockRepository interface.
+type MockILockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILockRepositoryMockRecorder
+}
+
+// MockILockRepositoryMockRecorder is the mock recorder for MockILockRepository.
+type MockILockRepositoryMockRecorder struct {
+	mock *MockILockRepository
+}
+
+// NewMockILockRepository creates a new mock instance.
+func NewMockILockRepository(ctrl *gomock.Controller) *MockILockRepository {
+	mock := &MockILockRepository{ctrl: ctrl}
+	mock.recorder = &MockILockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILockRepository) EXPECT() *MockILockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockILockRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, name, owner, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockILockRepositoryMockRecorder) Acquire(ctx, name, owner, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockILockRepository)(nil).Acquire), ctx, name, owner, ttl)
+}
+
+// Release mocks base method.
+func (m *MockILockRepository) Release(ctx context.Context, name, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, name, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockILockRepositoryMockRecorder) Release(ctx, name, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockILockRepository)(nil).Release), ctx, name, owner)
+}
@@ -50,6 +50,51 @@ func (mr *MockIPayLoanRepositoryMockRecorder) BatchInsert(ctx, datas interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsert", reflect.TypeOf((*MockIPayLoanRepository)(nil).BatchInsert), ctx, datas)
 }
 
+// CountOverdueByLoanIds mocks base method.
+func (m *MockIPayLoanRepository) CountOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.OverdueEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverdueByLoanIds", ctx, loanIds, timeNow)
+	ret0, _ := ret[0].([]entity.OverdueEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverdueByLoanIds indicates an expected call of CountOverdueByLoanIds.
+func (mr *MockIPayLoanRepositoryMockRecorder) CountOverdueByLoanIds(ctx, loanIds, timeNow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverdueByLoanIds", reflect.TypeOf((*MockIPayLoanRepository)(nil).CountOverdueByLoanIds), ctx, loanIds, timeNow)
+}
+
+// GetExposures mocks base method.
+func (m *MockIPayLoanRepository) GetExposures(ctx context.Context, asOf time.Time) ([]entity.ExposureEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExposures", ctx, asOf)
+	ret0, _ := ret[0].([]entity.ExposureEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExposures indicates an expected call of GetExposures.
+func (mr *MockIPayLoanRepositoryMockRecorder) GetExposures(ctx, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExposures", reflect.TypeOf((*MockIPayLoanRepository)(nil).GetExposures), ctx, asOf)
+}
+
+// GetFullyPaidLoanIds mocks base method.
+func (m *MockIPayLoanRepository) GetFullyPaidLoanIds(ctx context.Context, loanIds []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFullyPaidLoanIds", ctx, loanIds)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFullyPaidLoanIds indicates an expected call of GetFullyPaidLoanIds.
+func (mr *MockIPayLoanRepositoryMockRecorder) GetFullyPaidLoanIds(ctx, loanIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullyPaidLoanIds", reflect.TypeOf((*MockIPayLoanRepository)(nil).GetFullyPaidLoanIds), ctx, loanIds)
+}
+
 // GetInSpecificTimeAndStatus mocks base method.
 func (m *MockIPayLoanRepository) GetInSpecificTimeAndStatus(ctx context.Context, loanId int, timeNow time.Time) ([]entity.PayLoanEntity, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +110,21 @@ func (mr *MockIPayLoanRepositoryMockRecorder) GetInSpecificTimeAndStatus(ctx, lo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInSpecificTimeAndStatus", reflect.TypeOf((*MockIPayLoanRepository)(nil).GetInSpecificTimeAndStatus), ctx, loanId, timeNow)
 }
 
+// GetOverdueByLoanIds mocks base method.
+func (m *MockIPayLoanRepository) GetOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.PayLoanEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueByLoanIds", ctx, loanIds, timeNow)
+	ret0, _ := ret[0].([]entity.PayLoanEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueByLoanIds indicates an expected call of GetOverdueByLoanIds.
+func (mr *MockIPayLoanRepositoryMockRecorder) GetOverdueByLoanIds(ctx, loanIds, timeNow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueByLoanIds", reflect.TypeOf((*MockIPayLoanRepository)(nil).GetOverdueByLoanIds), ctx, loanIds, timeNow)
+}
+
 // GetPayLoanByLoanId mocks base method.
 func (m *MockIPayLoanRepository) GetPayLoanByLoanId(ctx context.Context, loandId int) ([]entity.PayLoanEntity, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +140,122 @@ func (mr *MockIPayLoanRepositoryMockRecorder) GetPayLoanByLoanId(ctx, loandId in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayLoanByLoanId", reflect.TypeOf((*MockIPayLoanRepository)(nil).GetPayLoanByLoanId), ctx, loandId)
 }
 
+// GetUnpaidAtByLoanIds mocks base method.
+func (m *MockIPayLoanRepository) GetUnpaidAtByLoanIds(ctx context.Context, loanIds []int, asOf time.Time) ([]entity.PayLoanEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpaidAtByLoanIds", ctx, loanIds, asOf)
+	ret0, _ := ret[0].([]entity.PayLoanEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpaidAtByLoanIds indicates an expected call of GetUnpaidAtByLoanIds.
+func (mr *MockIPayLoanRepositoryMockRecorder) GetUnpaidAtByLoanIds(ctx, loanIds, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpaidAtByLoanIds", reflect.TypeOf((*MockIPayLoanRepository)(nil).GetUnpaidAtByLoanIds), ctx, loanIds, asOf)
+}
+
+// ListByUsername mocks base method.
+func (m *MockIPayLoanRepository) ListByUsername(ctx context.Context, filter entity.HistoryFilter) ([]entity.PayLoanEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUsername", ctx, filter)
+	ret0, _ := ret[0].([]entity.PayLoanEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUsername indicates an expected call of ListByUsername.
+func (mr *MockIPayLoanRepositoryMockRecorder) ListByUsername(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUsername", reflect.TypeOf((*MockIPayLoanRepository)(nil).ListByUsername), ctx, filter)
+}
+
+// Reschedule mocks base method.
+func (m *MockIPayLoanRepository) Reschedule(ctx context.Context, datas []entity.PayLoanEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, datas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockIPayLoanRepositoryMockRecorder) Reschedule(ctx, datas interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockIPayLoanRepository)(nil).Reschedule), ctx, datas)
+}
+
+// StreamExposures mocks base method.
+func (m *MockIPayLoanRepository) StreamExposures(ctx context.Context, asOf time.Time, fn func(entity.ExposureEntity) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamExposures", ctx, asOf, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamExposures indicates an expected call of StreamExposures.
+func (mr *MockIPayLoanRepositoryMockRecorder) StreamExposures(ctx, asOf, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamExposures", reflect.TypeOf((*MockIPayLoanRepository)(nil).StreamExposures), ctx, asOf, fn)
+}
+
+// StreamPayments mocks base method.
+func (m *MockIPayLoanRepository) StreamPayments(ctx context.Context, filter entity.ExportFilter, fn func(entity.InstallmentRowEntity) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPayments", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPayments indicates an expected call of StreamPayments.
+func (mr *MockIPayLoanRepositoryMockRecorder) StreamPayments(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPayments", reflect.TypeOf((*MockIPayLoanRepository)(nil).StreamPayments), ctx, filter, fn)
+}
+
+// StreamSchedules mocks base method.
+func (m *MockIPayLoanRepository) StreamSchedules(ctx context.Context, filter entity.ExportFilter, fn func(entity.InstallmentRowEntity) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSchedules", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSchedules indicates an expected call of StreamSchedules.
+func (mr *MockIPayLoanRepositoryMockRecorder) StreamSchedules(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSchedules", reflect.TypeOf((*MockIPayLoanRepository)(nil).StreamSchedules), ctx, filter, fn)
+}
+
+// SumCollections mocks base method.
+func (m *MockIPayLoanRepository) SumCollections(ctx context.Context, from, to time.Time) ([]entity.CollectionEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumCollections", ctx, from, to)
+	ret0, _ := ret[0].([]entity.CollectionEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumCollections indicates an expected call of SumCollections.
+func (mr *MockIPayLoanRepositoryMockRecorder) SumCollections(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumCollections", reflect.TypeOf((*MockIPayLoanRepository)(nil).SumCollections), ctx, from, to)
+}
+
+// SumTax mocks base method.
+func (m *MockIPayLoanRepository) SumTax(ctx context.Context, from, to time.Time, status int) ([]entity.TaxSummaryEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTax", ctx, from, to, status)
+	ret0, _ := ret[0].([]entity.TaxSummaryEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTax indicates an expected call of SumTax.
+func (mr *MockIPayLoanRepositoryMockRecorder) SumTax(ctx, from, to, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTax", reflect.TypeOf((*MockIPayLoanRepository)(nil).SumTax), ctx, from, to, status)
+}
+
 // Update mocks base method.
 func (m *MockIPayLoanRepository) Update(ctx context.Context, id int, data entity.PayLoanEntity) error {
 	m.ctrl.T.Helper()
@@ -93,3 +269,17 @@ func (mr *MockIPayLoanRepositoryMockRecorder) Update(ctx, id, data interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIPayLoanRepository)(nil).Update), ctx, id, data)
 }
+
+// UpdateStatusByLoanId mocks base method.
+func (m *MockIPayLoanRepository) UpdateStatusByLoanId(ctx context.Context, loanId, fromStatus, toStatus int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByLoanId", ctx, loanId, fromStatus, toStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByLoanId indicates an expected call of UpdateStatusByLoanId.
+func (mr *MockIPayLoanRepositoryMockRecorder) UpdateStatusByLoanId(ctx, loanId, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByLoanId", reflect.TypeOf((*MockIPayLoanRepository)(nil).UpdateStatusByLoanId), ctx, loanId, fromStatus, toStatus)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/promo_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPromoRepository is a mock of IPromoRepository interface.
+type MockIPromoRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPromoRepositoryMockRecorder
+}
+
+// MockIPromoRepositoryMockRecorder is the mock recorder for MockIPromoRepository.
+type MockIPromoRepositoryMockRecorder struct {
+	mock *MockIPromoRepository
+}
+
+// NewMockIPromoRepository creates a new mock instance.
+func NewMockIPromoRepository(ctrl *gomock.Controller) *MockIPromoRepository {
+	mock := &MockIPromoRepository{ctrl: ctrl}
+	mock.recorder = &MockIPromoRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPromoRepository) EXPECT() *MockIPromoRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIPromoRepository) Get(ctx context.Context, code string) (entity.PromoEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, code)
+	ret0, _ := ret[0].(entity.PromoEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIPromoRepositoryMockRecorder) Get(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIPromoRepository)(nil).Get), ctx, code)
+}
+
+// Release mocks base method.
+func (m *MockIPromoRepository) Release(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIPromoRepositoryMockRecorder) Release(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIPromoRepository)(nil).Release), ctx, code)
+}
+
+// Use mocks base method.
+func (m *MockIPromoRepository) Use(ctx context.Context, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Use", ctx, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Use indicates an expected call of Use.
+func (mr *MockIPromoRepositoryMockRecorder) Use(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockIPromoRepository)(nil).Use), ctx, code)
+}
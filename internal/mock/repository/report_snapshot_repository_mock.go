@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/report_snapshot_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIReportSnapshotRepository is a mock of IReportSnapshotRepository interface.
+type MockIReportSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReportSnapshotRepositoryMockRecorder
+}
+
+// MockIReportSnapshotRepositoryMockRecorder is the mock recorder for MockIReportSnapshotRepository.
+type MockIReportSnapshotRepositoryMockRecorder struct {
+	mock *MockIReportSnapshotRepository
+}
+
+// NewMockIReportSnapshotRepository creates a new mock instance.
+func NewMockIReportSnapshotRepository(ctrl *gomock.Controller) *MockIReportSnapshotRepository {
+	mock := &MockIReportSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockIReportSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReportSnapshotRepository) EXPECT() *MockIReportSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByDate mocks base method.
+func (m *MockIReportSnapshotRepository) GetByDate(ctx context.Context, reportDate time.Time) ([]entity.ReportSnapshotEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDate", ctx, reportDate)
+	ret0, _ := ret[0].([]entity.ReportSnapshotEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDate indicates an expected call of GetByDate.
+func (mr *MockIReportSnapshotRepositoryMockRecorder) GetByDate(ctx, reportDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDate", reflect.TypeOf((*MockIReportSnapshotRepository)(nil).GetByDate), ctx, reportDate)
+}
+
+// Save mocks base method.
+func (m *MockIReportSnapshotRepository) Save(ctx context.Context, datas []entity.ReportSnapshotEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, datas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIReportSnapshotRepositoryMockRecorder) Save(ctx, datas interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIReportSnapshotRepository)(nil).Save), ctx, datas)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/statement_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIStatementRepository is a mock of IStatementRepository interface.
+type MockIStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatementRepositoryMockRecorder
+}
+
+// MockIStatementRepositoryMockRecorder is the mock recorder for MockIStatementRepository.
+type MockIStatementRepositoryMockRecorder struct {
+	mock *MockIStatementRepository
+}
+
+// NewMockIStatementRepository creates a new mock instance.
+func NewMockIStatementRepository(ctrl *gomock.Controller) *MockIStatementRepository {
+	mock := &MockIStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockIStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatementRepository) EXPECT() *MockIStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Exists mocks base method.
+func (m *MockIStatementRepository) Exists(ctx context.Context, loanId int, periodStart, periodEnd time.Time, format string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, loanId, periodStart, periodEnd, format)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockIStatementRepositoryMockRecorder) Exists(ctx, loanId, periodStart, periodEnd, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockIStatementRepository)(nil).Exists), ctx, loanId, periodStart, periodEnd, format)
+}
+
+// Get mocks base method.
+func (m *MockIStatementRepository) Get(ctx context.Context, loanId int, periodStart, periodEnd time.Time, format string) (entity.StatementEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, loanId, periodStart, periodEnd, format)
+	ret0, _ := ret[0].(entity.StatementEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIStatementRepositoryMockRecorder) Get(ctx, loanId, periodStart, periodEnd, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIStatementRepository)(nil).Get), ctx, loanId, periodStart, periodEnd, format)
+}
+
+// Save mocks base method.
+func (m *MockIStatementRepository) Save(ctx context.Context, data entity.StatementEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIStatementRepositoryMockRecorder) Save(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIStatementRepository)(nil).Save), ctx, data)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/tax_rule_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/billing-engine/internal/repository/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITaxRuleRepository is a mock of ITaxRuleRepository interface.
+type MockITaxRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaxRuleRepositoryMockRecorder
+}
+
+// MockITaxRuleRepositoryMockRecorder is the mock recorder for MockITaxRuleRepository.
+type MockITaxRuleRepositoryMockRecorder struct {
+	mock *MockITaxRuleRepository
+}
+
+// NewMockITaxRuleRepository creates a new mock instance.
+func NewMockITaxRuleRepository(ctrl *gomock.Controller) *MockITaxRuleRepository {
+	mock := &MockITaxRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockITaxRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaxRuleRepository) EXPECT() *MockITaxRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByCountry mocks base method.
+func (m *MockITaxRuleRepository) GetByCountry(ctx context.Context, country string) ([]entity.TaxRuleEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCountry", ctx, country)
+	ret0, _ := ret[0].([]entity.TaxRuleEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCountry indicates an expected call of GetByCountry.
+func (mr *MockITaxRuleRepositoryMockRecorder) GetByCountry(ctx, country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCountry", reflect.TypeOf((*MockITaxRuleRepository)(nil).GetByCountry), ctx, country)
+}
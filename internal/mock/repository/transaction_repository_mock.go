@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/transaction_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/billing-engine/internal/repository"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITransactionRepository is a mock of ITransactionRepository interface.
+type MockITransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransactionRepositoryMockRecorder
+}
+
+// MockITransactionRepositoryMockRecorder is the mock recorder for MockITransactionRepository.
+type MockITransactionRepositoryMockRecorder struct {
+	mock *MockITransactionRepository
+}
+
+// NewMockITransactionRepository creates a new mock instance.
+func NewMockITransactionRepository(ctrl *gomock.Controller) *MockITransactionRepository {
+	mock := &MockITransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockITransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransactionRepository) EXPECT() *MockITransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// WithTransaction mocks base method.
+func (m *MockITransactionRepository) WithTransaction(ctx context.Context, fn func(*repository.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockITransactionRepositoryMockRecorder) WithTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockITransactionRepository)(nil).WithTransaction), ctx, fn)
+}
@@ -36,18 +36,18 @@ func (m *MockIUserRepository) EXPECT() *MockIUserRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockIUserRepository) Create(ctx context.Context, username string) (entity.UserEntity, error) {
+func (m *MockIUserRepository) Create(ctx context.Context, data entity.UserEntity) (entity.UserEntity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, username)
+	ret := m.ctrl.Call(m, "Create", ctx, data)
 	ret0, _ := ret[0].(entity.UserEntity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIUserRepositoryMockRecorder) Create(ctx, username interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) Create(ctx, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserRepository)(nil).Create), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserRepository)(nil).Create), ctx, data)
 }
 
 // GetUser mocks base method.
@@ -65,6 +65,20 @@ func (mr *MockIUserRepositoryMockRecorder) GetUser(ctx, username interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUserRepository)(nil).GetUser), ctx, username)
 }
 
+// UpdateProfile mocks base method.
+func (m *MockIUserRepository) UpdateProfile(ctx context.Context, username string, data entity.UserEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, username, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockIUserRepositoryMockRecorder) UpdateProfile(ctx, username, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockIUserRepository)(nil).UpdateProfile), ctx, username, data)
+}
+
 // UpdateUser mocks base method.
 func (m *MockIUserRepository) UpdateUser(ctx context.Context, username string, status int) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,127 @@
+package reporting
+
+import (
+	"sort"
+	"time"
+)
+
+// days past due used for portfolio at risk and count of late installment for delinquent borrower,
+// same rule used by schedule task to flag user delinquent
+const (
+	Par30Days                = 30
+	Par90Days                = 90
+	DelinquentLateInstalment = 2
+)
+
+// Exposure is unpaid part of one loan as of report date
+type Exposure struct {
+	LoanId        int
+	Username      string
+	Currency      string
+	Outstanding   float64
+	OverdueCount  int
+	OldestDueDate time.Time
+}
+
+// Collection is installment paid on report date in one currency
+type Collection struct {
+	Currency string
+	Count    int
+	Amount   float64
+}
+
+// Metrics is portfolio report of one currency. Par30 and Par90 is ratio of outstanding
+// of loan past due more than 30 and 90 days to total outstanding
+type Metrics struct {
+	Currency            string
+	LoanCount           int
+	Outstanding         float64
+	Par30Amount         float64
+	Par90Amount         float64
+	Par30               float64
+	Par90               float64
+	DelinquentBorrowers int
+	CollectionCount     int
+	Collections         float64
+}
+
+// DaysPastDue return full days since oldest overdue installment, zero when nothing overdue
+func DaysPastDue(asOf time.Time, exposure Exposure) int {
+	if exposure.OverdueCount == 0 || !exposure.OldestDueDate.Before(asOf) {
+		return 0
+	}
+
+	return int(asOf.Sub(exposure.OldestDueDate).Hours() / 24)
+}
+
+// AgingBucket return aging bucket of days past due used by aging report
+func AgingBucket(daysPastDue int) string {
+	switch {
+	case daysPastDue <= 0:
+		return "current"
+	case daysPastDue <= Par30Days:
+		return "1-30"
+	case daysPastDue <= 60:
+		return "31-60"
+	case daysPastDue <= Par90Days:
+		return "61-90"
+	}
+
+	return "90+"
+}
+
+// Compute summarize exposures and collections as of end of report date per currency, ordered by currency
+func Compute(asOf time.Time, exposures []Exposure, collections []Collection) []Metrics {
+	metrics := map[string]*Metrics{}
+	get := func(currency string) *Metrics {
+		if _, ok := metrics[currency]; !ok {
+			metrics[currency] = &Metrics{Currency: currency}
+		}
+		return metrics[currency]
+	}
+
+	delinquents := map[string]map[string]bool{}
+	for _, exposure := range exposures {
+		m := get(exposure.Currency)
+		m.LoanCount++
+		m.Outstanding += exposure.Outstanding
+
+		daysPastDue := DaysPastDue(asOf, exposure)
+		if daysPastDue > Par30Days {
+			m.Par30Amount += exposure.Outstanding
+		}
+		if daysPastDue > Par90Days {
+			m.Par90Amount += exposure.Outstanding
+		}
+
+		if exposure.OverdueCount >= DelinquentLateInstalment {
+			if delinquents[exposure.Currency] == nil {
+				delinquents[exposure.Currency] = map[string]bool{}
+			}
+			delinquents[exposure.Currency][exposure.Username] = true
+		}
+	}
+
+	for _, collection := range collections {
+		m := get(collection.Currency)
+		m.CollectionCount += collection.Count
+		m.Collections += collection.Amount
+	}
+
+	result := []Metrics{}
+	for currency, m := range metrics {
+		m.DelinquentBorrowers = len(delinquents[currency])
+		if m.Outstanding > 0 {
+			m.Par30 = m.Par30Amount / m.Outstanding
+			m.Par90 = m.Par90Amount / m.Outstanding
+		}
+
+		result = append(result, *m)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Currency < result[j].Currency
+	})
+
+	return result
+}
@@ -0,0 +1,67 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaysPastDue(t *testing.T) {
+	asOf := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, DaysPastDue(asOf, Exposure{OverdueCount: 0, OldestDueDate: asOf.AddDate(0, 0, -40)}))
+	assert.Equal(t, 40, DaysPastDue(asOf, Exposure{OverdueCount: 1, OldestDueDate: asOf.AddDate(0, 0, -40)}))
+	assert.Equal(t, 0, DaysPastDue(asOf, Exposure{OverdueCount: 1, OldestDueDate: asOf.AddDate(0, 0, 7)}))
+}
+
+func TestCompute(t *testing.T) {
+	asOf := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("metrics per currency", func(t *testing.T) {
+		metrics := Compute(asOf, []Exposure{
+			// current loan
+			{LoanId: 1, Username: "a", Currency: "IDR", Outstanding: 600, OldestDueDate: asOf.AddDate(0, 0, 3)},
+			// 10 days past due with 2 late installment
+			{LoanId: 2, Username: "b", Currency: "IDR", Outstanding: 200, OverdueCount: 2, OldestDueDate: asOf.AddDate(0, 0, -10)},
+			// 45 days past due
+			{LoanId: 3, Username: "c", Currency: "IDR", Outstanding: 100, OverdueCount: 6, OldestDueDate: asOf.AddDate(0, 0, -45)},
+			// 120 days past due
+			{LoanId: 4, Username: "d", Currency: "IDR", Outstanding: 100, OverdueCount: 17, OldestDueDate: asOf.AddDate(0, 0, -120)},
+			{LoanId: 5, Username: "e", Currency: "USD", Outstanding: 50, OverdueCount: 1, OldestDueDate: asOf.AddDate(0, 0, -1)},
+		}, []Collection{
+			{Currency: "IDR", Count: 3, Amount: 330},
+			{Currency: "SGD", Count: 1, Amount: 10},
+		})
+
+		assert.Equal(t, []Metrics{
+			{
+				Currency:            "IDR",
+				LoanCount:           4,
+				Outstanding:         1000,
+				Par30Amount:         200,
+				Par90Amount:         100,
+				Par30:               0.2,
+				Par90:               0.1,
+				DelinquentBorrowers: 3,
+				CollectionCount:     3,
+				Collections:         330,
+			},
+			{Currency: "SGD", CollectionCount: 1, Collections: 10},
+			{Currency: "USD", LoanCount: 1, Outstanding: 50},
+		}, metrics)
+	})
+
+	t.Run("empty portfolio", func(t *testing.T) {
+		assert.Empty(t, Compute(asOf, nil, nil))
+	})
+}
+
+func TestAgingBucket(t *testing.T) {
+	assert.Equal(t, "current", AgingBucket(0))
+	assert.Equal(t, "1-30", AgingBucket(1))
+	assert.Equal(t, "1-30", AgingBucket(30))
+	assert.Equal(t, "31-60", AgingBucket(31))
+	assert.Equal(t, "61-90", AgingBucket(90))
+	assert.Equal(t, "90+", AgingBucket(91))
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// ExportFilter select rows of export in time range, zero From or To mean unbounded
+type ExportFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+// InstallmentRowEntity is installment with owner and currency of its loan
+type InstallmentRowEntity struct {
+	PayLoan  PayLoanEntity
+	Username string
+	Currency string
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// HistoryFilter select page of loan or installment of user ordered by time then id.
+// Empty Statuses mean every status, zero From or To mean unbounded
+type HistoryFilter struct {
+	Username   string
+	Statuses   []int
+	From       time.Time
+	To         time.Time
+	After      *HistoryCursor
+	Limit      int
+	Descending bool
+}
+
+// HistoryCursor is position of last row of previous page
+type HistoryCursor struct {
+	Time time.Time
+	Id   int
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+type HolidayEntity struct {
+	Id      int
+	Country string
+	Date    time.Time
+	Name    string
+}
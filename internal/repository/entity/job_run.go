@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// JobRunEntity is one run of scheduler job for scheduled time, zero FinishedAt mean still running
+type JobRunEntity struct {
+	Id          int
+	JobName     string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Status      int
+	Error       string
+}
@@ -3,9 +3,31 @@ package entity
 import "time"
 
 type LoanEntity struct {
-	Id        int
-	Username  string
-	Amount    float64
-	Status    int
-	CreatedAt time.Time
+	Id          int
+	Username    string
+	ProductCode string
+	Amount      float64
+	Status      int
+	CreatedAt   time.Time
+
+	WrittenOffPrincipal float64
+	WrittenOffInterest  float64
+	WrittenOffAt        time.Time
+	WriteOffReason      string
+	RecoveredAmount     float64
+
+	ClosedAt       time.Time
+	ClosedReason   string
+	RefinancedFrom int
+
+	OriginationFee  float64
+	ProcessingFee   float64
+	DisbursedAmount float64
+
+	OriginationFeeTax float64
+
+	PromoCode      string
+	DiscountAmount float64
+
+	Currency string
 }
@@ -0,0 +1,25 @@
+package entity
+
+type LoanProductEntity struct {
+	Code           string
+	Name           string
+	Interest       float64
+	Tenor          int
+	GracePeriods   int
+	GraceInterest  int
+	Country        string
+	RollConvention int
+	WriteOffDays   int
+	CoolingOffDays int
+
+	OriginationFee     float64
+	OriginationFeeType int
+	AdminFee           float64
+	ProcessingFee      float64
+
+	Currency string
+
+	// limit of loan amount, zero mean no limit
+	MinAmount float64
+	MaxAmount float64
+}
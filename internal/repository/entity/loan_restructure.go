@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type LoanRestructureEntity struct {
+	Id                int
+	LoanId            int
+	Outstanding       float64
+	Arrears           float64
+	Tenor             int
+	HolidayPeriods    int
+	CapitalizeArrears bool
+	CreatedAt         time.Time
+}
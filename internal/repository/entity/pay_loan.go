@@ -8,4 +8,21 @@ type PayLoanEntity struct {
 	Amount    float64
 	CreatedAt time.Time
 	Status    int
+	FeeAmount float64
+
+	TaxAmount            float64
+	WithholdingTaxAmount float64
+
+	DiscountAmount float64
+
+	// PaidAt is zero while installment not yet paid
+	PaidAt     time.Time
+	PaidAmount float64
+}
+
+// OverdueEntity is unpaid installments of one loan with due date already passed
+type OverdueEntity struct {
+	LoanId        int
+	OverdueCount  int
+	OldestDueDate time.Time
 }
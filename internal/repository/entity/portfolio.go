@@ -0,0 +1,8 @@
+package entity
+
+type PortfolioEntity struct {
+	Currency   string
+	LoanCount  int64
+	Amount     float64
+	PaidAmount float64
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type PromoEntity struct {
+	Code            string
+	Name            string
+	DiscountType    int
+	DiscountValue   float64
+	ValidFrom       time.Time
+	ValidTo         time.Time
+	UsageLimit      int
+	UsageCount      int
+	ProductCode     string
+	MinAmount       float64
+	MaxAmount       float64
+	NewBorrowerOnly bool
+}
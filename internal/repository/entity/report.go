@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// ExposureEntity is unpaid installments of one loan as of report date
+type ExposureEntity struct {
+	LoanId        int
+	Username      string
+	Currency      string
+	ProductCode   string
+	Outstanding   float64
+	OverdueCount  int
+	OldestDueDate time.Time
+}
+
+type CollectionEntity struct {
+	Currency string
+	Count    int
+	Amount   float64
+}
+
+type ReportSnapshotEntity struct {
+	ReportDate          time.Time
+	Currency            string
+	LoanCount           int
+	Outstanding         float64
+	Par30Amount         float64
+	Par90Amount         float64
+	Par30               float64
+	Par90               float64
+	DelinquentBorrowers int
+	CollectionCount     int
+	Collections         float64
+	CreatedAt           time.Time
+}
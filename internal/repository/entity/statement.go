@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// StatementEntity is rendered statement of loan, PeriodEnd is last day of period
+type StatementEntity struct {
+	Id          int
+	LoanId      int
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Format      string
+	Content     []byte
+	CreatedAt   time.Time
+}
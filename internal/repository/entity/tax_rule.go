@@ -0,0 +1,15 @@
+package entity
+
+type TaxRuleEntity struct {
+	Id      int
+	Country string
+	Type    int
+	Rate    float64
+	Name    string
+}
+
+type TaxSummaryEntity struct {
+	Currency             string
+	TaxAmount            float64
+	WithholdingTaxAmount float64
+}
@@ -1,6 +1,17 @@
 package entity
 
+import "time"
+
 type UserEntity struct {
-	Username string
-	Status   int
+	Username       string
+	FullName       string
+	NationalId     string
+	Phone          string
+	Email          string
+	Address        string
+	DateOfBirth    time.Time
+	KycStatus      int
+	KycSubmittedAt time.Time
+	KycVerifiedAt  time.Time
+	Status         int
 }
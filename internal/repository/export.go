@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/billing-engine/internal/repository/entity"
+	"gorm.io/gorm"
+)
+
+// streamRows scan result of query row by row and pass every row to fn, stop at first error of fn
+func streamRows[M any](query *gorm.DB, fn func(model M) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var model M
+		if err := query.ScanRows(rows, &model); err != nil {
+			return err
+		}
+
+		if err := fn(model); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// applyExportFilter filter query by time range of column
+func applyExportFilter(query *gorm.DB, filter entity.ExportFilter, timeColumn string) *gorm.DB {
+	if !filter.From.IsZero() {
+		query = query.Where(timeColumn+" >= ?", filter.From.Format("2006-01-02 15:04:05"))
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where(timeColumn+" < ?", filter.To.Format("2006-01-02 15:04:05"))
+	}
+
+	return query
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"gorm.io/gorm"
+)
+
+// applyHistoryFilter filter query by status and time range, and continue after cursor using keyset on time then id
+func applyHistoryFilter(query *gorm.DB, filter entity.HistoryFilter, statusColumn string, timeColumn string, idColumn string) *gorm.DB {
+	if len(filter.Statuses) > 0 {
+		query = query.Where(statusColumn+" IN ?", filter.Statuses)
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where(timeColumn+" >= ?", filter.From.Format("2006-01-02 15:04:05"))
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where(timeColumn+" < ?", filter.To.Format("2006-01-02 15:04:05"))
+	}
+
+	direction, operator := "ASC", ">"
+	if filter.Descending {
+		direction, operator = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		after := filter.After.Time.Format("2006-01-02 15:04:05")
+		query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", timeColumn, operator, timeColumn, idColumn, operator), after, after, filter.After.Id)
+	}
+
+	return query.Order(fmt.Sprintf("%s %s, %s %s", timeColumn, direction, idColumn, direction)).Limit(filter.Limit)
+}
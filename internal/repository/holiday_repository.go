@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type IHolidayRepository interface {
+	GetByCountry(ctx context.Context, country string) ([]entity.HolidayEntity, error)
+}
+
+type HolidayRepository struct {
+	DB *gorm.DB
+}
+
+func NewHolidayRepository(DB *gorm.DB) IHolidayRepository {
+	return &HolidayRepository{
+		DB: DB,
+	}
+}
+
+func (hr *HolidayRepository) GetByCountry(ctx context.Context, country string) ([]entity.HolidayEntity, error) {
+	models := []models.HolidayModel{}
+
+	if response := hr.DB.Table("holiday").Where("country = ?", country).Find(&models); response.Error != nil {
+		return []entity.HolidayEntity{}, response.Error
+	}
+
+	result := []entity.HolidayEntity{}
+	for _, model := range models {
+		result = append(result, convertModelToEntityHoliday(model))
+	}
+
+	return result, nil
+}
+
+func convertModelToEntityHoliday(model models.HolidayModel) entity.HolidayEntity {
+	date, _ := time.Parse("2006-01-02", model.Date)
+
+	return entity.HolidayEntity{
+		Id:      model.Id,
+		Country: model.Country,
+		Date:    date,
+		Name:    model.Name,
+	}
+}
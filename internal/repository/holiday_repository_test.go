@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestHolidayRepository_GetByCountry(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewHolidayRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "country", "date", "name"}).
+			AddRow(1, "ID", "2024-08-17", "Hari Kemerdekaan").
+			AddRow(2, "ID", "2024-12-25", "Hari Natal")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `holiday` WHERE country = ?")).
+			WithArgs("ID").
+			WillReturnRows(rows)
+
+		results, err := repo.GetByCountry(context.Background(), "ID")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, time.Date(2024, 8, 17, 0, 0, 0, 0, time.UTC), results[0].Date)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `holiday` WHERE country = ?")).
+			WithArgs("ID").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetByCountry(context.Background(), "ID")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IJobRunRepository interface {
+	Start(ctx context.Context, data entity.JobRunEntity) (entity.JobRunEntity, bool, error)
+	Finish(ctx context.Context, id int, status int, message string, finishedAt time.Time) error
+	GetLast(ctx context.Context, jobName string) (entity.JobRunEntity, error)
+	ListByJob(ctx context.Context, jobName string, limit int) ([]entity.JobRunEntity, error)
+}
+
+type JobRunRepository struct {
+	DB *gorm.DB
+}
+
+func NewJobRunRepository(DB *gorm.DB) IJobRunRepository {
+	return &JobRunRepository{
+		DB: DB,
+	}
+}
+
+// Start insert run of job for scheduled time, false is returned when the scheduled time already run
+// so the same run never executed twice even after restart
+func (jrr *JobRunRepository) Start(ctx context.Context, data entity.JobRunEntity) (entity.JobRunEntity, bool, error) {
+	model := convertEntityToModelJobRun(data)
+
+	response := jrr.DB.Table("job_runs").Clauses(clause.Insert{Modifier: "IGNORE"}).Create(&model)
+	if response.Error != nil {
+		return entity.JobRunEntity{}, false, response.Error
+	}
+
+	if response.RowsAffected == 0 {
+		return entity.JobRunEntity{}, false, nil
+	}
+
+	return convertModelToEntityJobRun(model), true, nil
+}
+
+func (jrr *JobRunRepository) Finish(ctx context.Context, id int, status int, message string, finishedAt time.Time) error {
+	values := map[string]interface{}{
+		"status":      status,
+		"finished_at": finishedAt.Format("2006-01-02 15:04:05"),
+		"error":       nil,
+	}
+	if message != "" {
+		values["error"] = message
+	}
+
+	if response := jrr.DB.Table("job_runs").Where("id = ?", id).Updates(values); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+// GetLast return latest scheduled run of job, zero entity when job never run
+func (jrr *JobRunRepository) GetLast(ctx context.Context, jobName string) (entity.JobRunEntity, error) {
+	models := []models.JobRunModel{}
+
+	if response := jrr.DB.Table("job_runs").Where("job_name = ?", jobName).Order("scheduled_at DESC").Limit(1).Find(&models); response.Error != nil {
+		return entity.JobRunEntity{}, response.Error
+	}
+
+	if len(models) == 0 {
+		return entity.JobRunEntity{}, nil
+	}
+
+	return convertModelToEntityJobRun(models[0]), nil
+}
+
+// ListByJob return latest runs of job, newest first
+func (jrr *JobRunRepository) ListByJob(ctx context.Context, jobName string, limit int) ([]entity.JobRunEntity, error) {
+	models := []models.JobRunModel{}
+
+	if response := jrr.DB.Table("job_runs").Where("job_name = ?", jobName).Order("scheduled_at DESC").Limit(limit).Find(&models); response.Error != nil {
+		return []entity.JobRunEntity{}, response.Error
+	}
+
+	result := []entity.JobRunEntity{}
+	for _, model := range models {
+		result = append(result, convertModelToEntityJobRun(model))
+	}
+
+	return result, nil
+}
+
+func convertEntityToModelJobRun(data entity.JobRunEntity) models.JobRunModel {
+	model := models.JobRunModel{
+		Id:          data.Id,
+		JobName:     data.JobName,
+		ScheduledAt: data.ScheduledAt.Format("2006-01-02 15:04:05"),
+		StartedAt:   data.StartedAt.Format("2006-01-02 15:04:05"),
+		FinishedAt:  formatNullableTime("2006-01-02 15:04:05", data.FinishedAt),
+		Status:      data.Status,
+	}
+	if data.Error != "" {
+		model.Error = &data.Error
+	}
+
+	return model
+}
+
+func convertModelToEntityJobRun(model models.JobRunModel) entity.JobRunEntity {
+	scheduledAt, _ := time.Parse("2006-01-02 15:04:05", model.ScheduledAt)
+	startedAt, _ := time.Parse("2006-01-02 15:04:05", model.StartedAt)
+
+	data := entity.JobRunEntity{
+		Id:          model.Id,
+		JobName:     model.JobName,
+		ScheduledAt: scheduledAt,
+		StartedAt:   startedAt,
+		FinishedAt:  parseNullableTime("2006-01-02 15:04:05", model.FinishedAt),
+		Status:      model.Status,
+	}
+	if model.Error != nil {
+		data.Error = *model.Error
+	}
+
+	return data
+}
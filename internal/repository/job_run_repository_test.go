@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestJobRunRepository_Start(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewJobRunRepository(db)
+	data := entity.JobRunEntity{
+		JobName:     "report_snapshot",
+		ScheduledAt: time.Date(2024, 3, 5, 0, 5, 0, 0, time.UTC),
+		StartedAt:   time.Date(2024, 3, 5, 0, 5, 3, 0, time.UTC),
+		Status:      commons.StatusJobRunRunning,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO `job_runs` (`job_name`,`scheduled_at`,`started_at`,`finished_at`,`status`,`error`) VALUES (?,?,?,?,?,?)")).
+			WithArgs("report_snapshot", "2024-03-05 00:05:00", "2024-03-05 00:05:03", nil, commons.StatusJobRunRunning, nil).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+
+		result, started, err := repo.Start(context.Background(), data)
+
+		assert.NoError(t, err)
+		assert.True(t, started)
+		assert.Equal(t, 7, result.Id)
+	})
+
+	t.Run("already run", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO `job_runs`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		_, started, err := repo.Start(context.Background(), data)
+
+		assert.NoError(t, err)
+		assert.False(t, started)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO `job_runs`")).
+			WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		_, _, err := repo.Start(context.Background(), data)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJobRunRepository_Finish(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewJobRunRepository(db)
+	finishedAt := time.Date(2024, 3, 5, 0, 6, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `job_runs` SET `error`=?,`finished_at`=?,`status`=? WHERE id = ?")).
+			WithArgs("database down", "2024-03-05 00:06:00", commons.StatusJobRunFailed, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Finish(context.Background(), 7, commons.StatusJobRunFailed, "database down", finishedAt)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `job_runs`")).
+			WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		err := repo.Finish(context.Background(), 7, commons.StatusJobRunSuccess, "", finishedAt)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJobRunRepository_GetLast(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewJobRunRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "job_name", "scheduled_at", "started_at", "finished_at", "status", "error"}).
+			AddRow(7, "report_snapshot", "2024-03-05 00:05:00", "2024-03-05 00:05:03", "2024-03-05 00:06:00", commons.StatusJobRunFailed, "database down")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_runs` WHERE job_name = ? ORDER BY scheduled_at DESC LIMIT ?")).
+			WithArgs("report_snapshot", 1).
+			WillReturnRows(rows)
+
+		result, err := repo.GetLast(context.Background(), "report_snapshot")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 5, 0, 5, 0, 0, time.UTC), result.ScheduledAt)
+		assert.Equal(t, time.Date(2024, 3, 5, 0, 6, 0, 0, time.UTC), result.FinishedAt)
+		assert.Equal(t, "database down", result.Error)
+	})
+
+	t.Run("never run", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_runs`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result, err := repo.GetLast(context.Background(), "report_snapshot")
+
+		assert.NoError(t, err)
+		assert.True(t, result.ScheduledAt.IsZero())
+	})
+}
+
+func TestJobRunRepository_ListByJob(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewJobRunRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "job_name", "scheduled_at", "started_at", "finished_at", "status", "error"}).
+			AddRow(8, "schedule_task", "2024-03-05 00:06:00", "2024-03-05 00:06:01", nil, commons.StatusJobRunRunning, nil).
+			AddRow(7, "schedule_task", "2024-03-05 00:05:30", "2024-03-05 00:05:31", "2024-03-05 00:05:32", commons.StatusJobRunSuccess, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_runs` WHERE job_name = ? ORDER BY scheduled_at DESC LIMIT ?")).
+			WithArgs("schedule_task", 20).
+			WillReturnRows(rows)
+
+		results, err := repo.ListByJob(context.Background(), "schedule_task", 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.True(t, results[0].FinishedAt.IsZero())
+		assert.Equal(t, commons.StatusJobRunSuccess, results[1].Status)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `job_runs`")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.ListByJob(context.Background(), "schedule_task", 20)
+
+		assert.Error(t, err)
+	})
+}
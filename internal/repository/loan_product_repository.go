@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type ILoanProductRepository interface {
+	Get(ctx context.Context, code string) (entity.LoanProductEntity, error)
+}
+
+type LoanProductRepository struct {
+	DB *gorm.DB
+}
+
+func NewLoanProductRepository(DB *gorm.DB) ILoanProductRepository {
+	return &LoanProductRepository{
+		DB: DB,
+	}
+}
+
+func (lpr *LoanProductRepository) Get(ctx context.Context, code string) (entity.LoanProductEntity, error) {
+	model := models.LoanProductModel{}
+
+	if response := lpr.DB.Table("loan_product").Where("code = ?", code).Find(&model); response.Error != nil {
+		return entity.LoanProductEntity{}, response.Error
+	}
+
+	return convertModelToEntityLoanProduct(model), nil
+}
+
+func convertModelToEntityLoanProduct(model models.LoanProductModel) entity.LoanProductEntity {
+	return entity.LoanProductEntity{
+		Code:           model.Code,
+		Name:           model.Name,
+		Interest:       model.Interest,
+		Tenor:          model.Tenor,
+		GracePeriods:   model.GracePeriods,
+		GraceInterest:  model.GraceInterest,
+		Country:        model.Country,
+		RollConvention: model.RollConvention,
+		WriteOffDays:   model.WriteOffDays,
+		CoolingOffDays: model.CoolingOffDays,
+
+		OriginationFee:     model.OriginationFee,
+		OriginationFeeType: model.OriginationFeeType,
+		AdminFee:           model.AdminFee,
+		ProcessingFee:      model.ProcessingFee,
+
+		Currency: model.Currency,
+
+		MinAmount: model.MinAmount,
+		MaxAmount: model.MaxAmount,
+	}
+}
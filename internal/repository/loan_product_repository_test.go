@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestLoanProductRepository_Get(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanProductRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"code", "name", "interest", "tenor", "grace_periods", "grace_interest", "country", "roll_convention", "min_amount", "max_amount"}).
+			AddRow("default", "Weekly Loan 50 Weeks", 10.0, 50, 2, commons.InterestHandlingAccrued, "ID", commons.RollConventionModifiedFollowing, 1000000.0, 50000000.0)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan_product` WHERE code = ?")).
+			WithArgs("default").
+			WillReturnRows(rows)
+
+		result, err := repo.Get(context.Background(), "default")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "default", result.Code)
+		assert.Equal(t, float64(10), result.Interest)
+		assert.Equal(t, 50, result.Tenor)
+		assert.Equal(t, 2, result.GracePeriods)
+		assert.Equal(t, commons.InterestHandlingAccrued, result.GraceInterest)
+		assert.Equal(t, "ID", result.Country)
+		assert.Equal(t, commons.RollConventionModifiedFollowing, result.RollConvention)
+		assert.Equal(t, float64(1000000), result.MinAmount)
+		assert.Equal(t, float64(50000000), result.MaxAmount)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan_product` WHERE code = ?")).
+			WithArgs("default").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.Get(context.Background(), "default")
+
+		assert.Error(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/billing-engine/internal/commons"
 	"github.com/billing-engine/internal/repository/entity"
 	"github.com/billing-engine/internal/repository/models"
 	"gorm.io/gorm"
@@ -12,8 +13,19 @@ import (
 type ILoanRepository interface {
 	CreateLoan(ctx context.Context, data entity.LoanEntity) (entity.LoanEntity, error)
 	Get(ctx context.Context, username string, status int) (entity.LoanEntity, error)
+	GetById(ctx context.Context, loanId int) (entity.LoanEntity, error)
+	ListByUsername(ctx context.Context, filter entity.HistoryFilter) ([]entity.LoanEntity, error)
 	UpdateStatus(ctx context.Context, loanId int, status int) error
 	GetByStatus(ctx context.Context, status int) ([]entity.LoanEntity, error)
+	GetByStatusAfterId(ctx context.Context, status int, afterId int, limit int) ([]entity.LoanEntity, error)
+	UpdateAmount(ctx context.Context, loanId int, amount float64) error
+	WriteOff(ctx context.Context, loanId int, data entity.LoanEntity) (bool, error)
+	AddRecovery(ctx context.Context, loanId int, amount float64) error
+	Close(ctx context.Context, loanId int, data entity.LoanEntity) (bool, error)
+	CountByUsernameAndStatus(ctx context.Context, username string, status int) (int64, error)
+	SumOriginationFeeTax(ctx context.Context, from time.Time, to time.Time) ([]entity.TaxSummaryEntity, error)
+	GetPortfolioByCurrency(ctx context.Context, status int) ([]entity.PortfolioEntity, error)
+	StreamLoans(ctx context.Context, filter entity.ExportFilter, fn func(data entity.LoanEntity) error) error
 }
 
 type LoanRepository struct {
@@ -40,6 +52,101 @@ func (lr *LoanRepository) UpdateStatus(ctx context.Context, loanId int, status i
 	return nil
 }
 
+func (lr *LoanRepository) UpdateAmount(ctx context.Context, loanId int, amount float64) error {
+	model := models.LoanModel{
+		Id: loanId,
+	}
+
+	if response := lr.DB.Table("loan").Model(&model).Updates(map[string]interface{}{
+		"amount": amount,
+	}); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+// WriteOff move loan to charged off and record the written off balance
+// WriteOff charge off loan only while it still open, return false when loan already left open status
+// so writing off twice change nothing
+func (lr *LoanRepository) WriteOff(ctx context.Context, loanId int, data entity.LoanEntity) (bool, error) {
+	model := models.LoanModel{
+		Id: loanId,
+	}
+
+	response := lr.DB.Table("loan").Model(&model).Where("status = ?", commons.StatusLoanNew).Updates(map[string]interface{}{
+		"status":                commons.StatusLoanChargedOff,
+		"written_off_principal": data.WrittenOffPrincipal,
+		"written_off_interest":  data.WrittenOffInterest,
+		"written_off_at":        formatNullableTime("2006-01-02 15:04:05", data.WrittenOffAt),
+		"write_off_reason":      data.WriteOffReason,
+	})
+	if response.Error != nil {
+		return false, response.Error
+	}
+
+	return response.RowsAffected > 0, nil
+}
+
+func (lr *LoanRepository) AddRecovery(ctx context.Context, loanId int, amount float64) error {
+	model := models.LoanModel{
+		Id: loanId,
+	}
+
+	if response := lr.DB.Table("loan").Model(&model).Update("recovered_amount", gorm.Expr("recovered_amount + ?", amount)); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+// Close end the loan before schedule finished with status and reason, e.g. cancelled. Loan is closed only while
+// it still open, return false when loan already left open status so loan is never closed twice
+func (lr *LoanRepository) Close(ctx context.Context, loanId int, data entity.LoanEntity) (bool, error) {
+	model := models.LoanModel{
+		Id: loanId,
+	}
+
+	response := lr.DB.Table("loan").Model(&model).Where("status = ?", commons.StatusLoanNew).Updates(map[string]interface{}{
+		"status":        data.Status,
+		"closed_at":     formatNullableTime("2006-01-02 15:04:05", data.ClosedAt),
+		"closed_reason": data.ClosedReason,
+	})
+	if response.Error != nil {
+		return false, response.Error
+	}
+
+	return response.RowsAffected > 0, nil
+}
+
+func (lr *LoanRepository) CountByUsernameAndStatus(ctx context.Context, username string, status int) (int64, error) {
+	var count int64
+
+	if response := lr.DB.Table("loan").Where("username = ?", username).Where("status = ?", status).Count(&count); response.Error != nil {
+		return 0, response.Error
+	}
+
+	return count, nil
+}
+
+// SumOriginationFeeTax return tax of origination fee from loan booked in date range as tax amount grouped by currency,
+// cancelled loan excluded
+func (lr *LoanRepository) SumOriginationFeeTax(ctx context.Context, from time.Time, to time.Time) ([]entity.TaxSummaryEntity, error) {
+	models := []models.TaxSummaryModel{}
+
+	if response := lr.DB.Table("loan").
+		Select("currency, COALESCE(SUM(origination_fee_tax), 0) AS tax_amount").
+		Where("created_at >= ?", from.Format("2006-01-02 15:04:05")).
+		Where("created_at < ?", to.Format("2006-01-02 15:04:05")).
+		Where("status <> ?", commons.StatusLoanCancelled).
+		Group("currency").
+		Scan(&models); response.Error != nil {
+		return []entity.TaxSummaryEntity{}, response.Error
+	}
+
+	return convertModelsToEntitiesTaxSummary(models), nil
+}
+
 func (lr *LoanRepository) GetByStatus(ctx context.Context, status int) ([]entity.LoanEntity, error) {
 	models := []models.LoanModel{}
 
@@ -50,12 +157,43 @@ func (lr *LoanRepository) GetByStatus(ctx context.Context, status int) ([]entity
 	return convertBulkModelToEntitiesLoan(models), nil
 }
 
+// GetByStatusAfterId return page of loan with id greater than afterId ordered by id,
+// next page start after id of last loan so loan changing status during scan not shift the page
+func (lr *LoanRepository) GetByStatusAfterId(ctx context.Context, status int, afterId int, limit int) ([]entity.LoanEntity, error) {
+	models := []models.LoanModel{}
+
+	if response := lr.DB.Table("loan").
+		Where("status = ?", status).
+		Where("id > ?", afterId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&models); response.Error != nil {
+		return []entity.LoanEntity{}, response.Error
+	}
+
+	return convertBulkModelToEntitiesLoan(models), nil
+}
+
 func (lr *LoanRepository) CreateLoan(ctx context.Context, data entity.LoanEntity) (entity.LoanEntity, error) {
 	model := models.LoanModel{
-		Username:  data.Username,
-		Amount:    data.Amount,
-		CreatedAt: data.CreatedAt.Format("2006-01-02 15:04:05"),
-		Status:    data.Status,
+		Username:    data.Username,
+		ProductCode: data.ProductCode,
+		Amount:      data.Amount,
+		CreatedAt:   data.CreatedAt.Format("2006-01-02 15:04:05"),
+		Status:      data.Status,
+
+		RefinancedFrom: data.RefinancedFrom,
+
+		OriginationFee:  data.OriginationFee,
+		ProcessingFee:   data.ProcessingFee,
+		DisbursedAmount: data.DisbursedAmount,
+
+		OriginationFeeTax: data.OriginationFeeTax,
+
+		PromoCode:      data.PromoCode,
+		DiscountAmount: data.DiscountAmount,
+
+		Currency: data.Currency,
 	}
 	if err := lr.DB.Table("loan").Create(&model); err.Error != nil {
 		return entity.LoanEntity{}, err.Error
@@ -67,21 +205,103 @@ func (lr *LoanRepository) CreateLoan(ctx context.Context, data entity.LoanEntity
 func (lr *LoanRepository) Get(ctx context.Context, username string, status int) (entity.LoanEntity, error) {
 	model := models.LoanModel{}
 	if response := lr.DB.Table("loan").Where("username = ?", username).Where("status  = ?", status).Last(&model); response.Error != nil {
-		return entity.LoanEntity{}, response.Error
+		return entity.LoanEntity{}, translateError(response.Error)
+	}
+
+	return convertModelToEntityLoan(model), nil
+}
+
+func (lr *LoanRepository) GetById(ctx context.Context, loanId int) (entity.LoanEntity, error) {
+	model := models.LoanModel{}
+	if response := lr.DB.Table("loan").Where("id = ?", loanId).First(&model); response.Error != nil {
+		return entity.LoanEntity{}, translateError(response.Error)
 	}
 
 	return convertModelToEntityLoan(model), nil
 }
 
+// ListByUsername return page of loan of user ordered by created time
+func (lr *LoanRepository) ListByUsername(ctx context.Context, filter entity.HistoryFilter) ([]entity.LoanEntity, error) {
+	models := []models.LoanModel{}
+
+	query := lr.DB.Table("loan").Where("username = ?", filter.Username)
+	if response := applyHistoryFilter(query, filter, "status", "created_at", "id").Find(&models); response.Error != nil {
+		return []entity.LoanEntity{}, response.Error
+	}
+
+	result := []entity.LoanEntity{}
+	for _, model := range models {
+		result = append(result, convertModelToEntityLoan(model))
+	}
+
+	return result, nil
+}
+
+// StreamLoans pass loan booked in time range ordered by id to fn one by one
+func (lr *LoanRepository) StreamLoans(ctx context.Context, filter entity.ExportFilter, fn func(data entity.LoanEntity) error) error {
+	query := applyExportFilter(lr.DB.Table("loan"), filter, "created_at").Order("id")
+
+	return streamRows(query, func(model models.LoanModel) error {
+		return fn(convertModelToEntityLoan(model))
+	})
+}
+
+// GetPortfolioByCurrency sum amount and paid installments of loan with status grouped by currency
+func (lr *LoanRepository) GetPortfolioByCurrency(ctx context.Context, status int) ([]entity.PortfolioEntity, error) {
+	models := []models.PortfolioModel{}
+
+	if response := lr.DB.Raw(`SELECT loan.currency AS currency, COUNT(*) AS loan_count, SUM(loan.amount) AS amount, COALESCE(SUM(paid.amount), 0) AS paid_amount
+		FROM loan
+		LEFT JOIN (SELECT loan_id, SUM(amount) AS amount FROM pay_loan WHERE status = ? GROUP BY loan_id) paid ON paid.loan_id = loan.id
+		WHERE loan.status = ?
+		GROUP BY loan.currency`, commons.StatusPayLoanPayed, status).Scan(&models); response.Error != nil {
+		return []entity.PortfolioEntity{}, response.Error
+	}
+
+	result := []entity.PortfolioEntity{}
+	for _, model := range models {
+		result = append(result, entity.PortfolioEntity{
+			Currency:   model.Currency,
+			LoanCount:  model.LoanCount,
+			Amount:     model.Amount,
+			PaidAmount: model.PaidAmount,
+		})
+	}
+
+	return result, nil
+}
+
 func convertModelToEntityLoan(model models.LoanModel) entity.LoanEntity {
 	createdAt, _ := time.Parse("2006-01-02 15:04:05", model.CreatedAt)
 
 	return entity.LoanEntity{
-		Id:        model.Id,
-		Username:  model.Username,
-		Amount:    model.Amount,
-		CreatedAt: createdAt,
-		Status:    model.Status,
+		Id:          model.Id,
+		Username:    model.Username,
+		ProductCode: model.ProductCode,
+		Amount:      model.Amount,
+		CreatedAt:   createdAt,
+		Status:      model.Status,
+
+		WrittenOffPrincipal: model.WrittenOffPrincipal,
+		WrittenOffInterest:  model.WrittenOffInterest,
+		WrittenOffAt:        parseNullableTime("2006-01-02 15:04:05", model.WrittenOffAt),
+		WriteOffReason:      model.WriteOffReason,
+		RecoveredAmount:     model.RecoveredAmount,
+
+		ClosedAt:       parseNullableTime("2006-01-02 15:04:05", model.ClosedAt),
+		ClosedReason:   model.ClosedReason,
+		RefinancedFrom: model.RefinancedFrom,
+
+		OriginationFee:  model.OriginationFee,
+		ProcessingFee:   model.ProcessingFee,
+		DisbursedAmount: model.DisbursedAmount,
+
+		OriginationFeeTax: model.OriginationFeeTax,
+
+		PromoCode:      model.PromoCode,
+		DiscountAmount: model.DiscountAmount,
+
+		Currency: model.Currency,
 	}
 }
 
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/commons"
 	"github.com/billing-engine/internal/repository/entity"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -19,15 +21,16 @@ func TestLoanRepository_CreateLoan(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		data := entity.LoanEntity{
-			Username:  "user123",
-			Amount:    1000.0,
-			CreatedAt: time.Now(),
-			Status:    1,
+			Username:    "user123",
+			ProductCode: "default",
+			Amount:      1000.0,
+			CreatedAt:   time.Now(),
+			Status:      1,
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `loan` (`username`,`amount`,`created_at`,`status`) VALUES (?,?,?,?)")).
-			WithArgs(data.Username, data.Amount, data.CreatedAt.Format("2006-01-02 15:04:05"), data.Status).
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `loan` (`username`,`product_code`,`amount`,`created_at`,`status`,`written_off_principal`,`written_off_interest`,`written_off_at`,`write_off_reason`,`recovered_amount`,`closed_at`,`closed_reason`,`refinanced_from`,`origination_fee`,`processing_fee`,`disbursed_amount`,`origination_fee_tax`,`promo_code`,`discount_amount`,`currency`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+			WithArgs(data.Username, data.ProductCode, data.Amount, data.CreatedAt.Format("2006-01-02 15:04:05"), data.Status, float64(0), float64(0), nil, "", float64(0), nil, "", 0, float64(0), float64(0), float64(0), float64(0), "", float64(0), "").
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -35,20 +38,22 @@ func TestLoanRepository_CreateLoan(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, data.Username, result.Username)
+		assert.Equal(t, data.ProductCode, result.ProductCode)
 		assert.Equal(t, data.Status, result.Status)
 	})
 
 	t.Run("error", func(t *testing.T) {
 		data := entity.LoanEntity{
-			Username:  "user123",
-			Amount:    1000.0,
-			CreatedAt: time.Now(),
-			Status:    1,
+			Username:    "user123",
+			ProductCode: "default",
+			Amount:      1000.0,
+			CreatedAt:   time.Now(),
+			Status:      1,
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `loan` (`username`,`amount`,`created_at`,`status`) VALUES (?,?,?,?)")).
-			WithArgs(data.Username, data.Amount, data.CreatedAt.Format("2006-01-02 15:04:05"), data.Status).
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `loan` (`username`,`product_code`,`amount`,`created_at`,`status`,`written_off_principal`,`written_off_interest`,`written_off_at`,`write_off_reason`,`recovered_amount`,`closed_at`,`closed_reason`,`refinanced_from`,`origination_fee`,`processing_fee`,`disbursed_amount`,`origination_fee_tax`,`promo_code`,`discount_amount`,`currency`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+			WithArgs(data.Username, data.ProductCode, data.Amount, data.CreatedAt.Format("2006-01-02 15:04:05"), data.Status, float64(0), float64(0), nil, "", float64(0), nil, "", 0, float64(0), float64(0), float64(0), float64(0), "", float64(0), "").
 			WillReturnError(gorm.ErrInvalidData)
 		mock.ExpectRollback()
 
@@ -95,6 +100,38 @@ func TestLoanRepository_Get(t *testing.T) {
 	})
 }
 
+func TestLoanRepository_GetById(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		row := sqlmock.NewRows([]string{"id", "username", "amount", "status", "currency"}).
+			AddRow(123, "user123", 1100.0, 1, "IDR")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE id = ? ORDER BY `loan`.`id` LIMIT ?")).
+			WithArgs(123, 1).
+			WillReturnRows(row)
+
+		result, err := repo.GetById(context.Background(), 123)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 123, result.Id)
+		assert.Equal(t, "user123", result.Username)
+		assert.Equal(t, "IDR", result.Currency)
+	})
+
+	t.Run("error not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE id = ? ORDER BY `loan`.`id` LIMIT ?")).
+			WithArgs(123, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.GetById(context.Background(), 123)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
 func TestLoanRepository_UpdateStatus(t *testing.T) {
 	db, mock := setupTestDB(t)
 
@@ -169,3 +206,412 @@ func TestLoanRepository_GetByStatus(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestLoanRepository_GetByStatusAfterId(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "username", "amount", "status", "created_at"}).
+			AddRow(11, "user123", 1000.0, 0, "2023-08-24 10:00:00").
+			AddRow(12, "user456", 2000.0, 0, "2023-08-24 11:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE status = ? AND id > ? ORDER BY id ASC LIMIT ?")).
+			WithArgs(0, 10, 2).
+			WillReturnRows(rows)
+
+		results, err := repo.GetByStatusAfterId(context.Background(), 0, 10, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, 11, results[0].Id)
+		assert.Equal(t, 12, results[1].Id)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE status = ? AND id > ?")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetByStatusAfterId(context.Background(), 0, 10, 2)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoanRepository_UpdateAmount(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `amount`=? WHERE `id` = ?")).
+			WithArgs(1500.0, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateAmount(context.Background(), 1, 1500.0)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `amount`=? WHERE `id` = ?")).
+			WithArgs(1500.0, 1).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		err := repo.UpdateAmount(context.Background(), 1, 1500.0)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLoanRepository_WriteOff(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	data := entity.LoanEntity{
+		WrittenOffPrincipal: 1000.0,
+		WrittenOffInterest:  100.0,
+		WrittenOffAt:        time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC),
+		WriteOffReason:      "borrower passed away",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `status`=?,`write_off_reason`=?,`written_off_at`=?,`written_off_interest`=?,`written_off_principal`=? WHERE status = ? AND `id` = ?")).
+			WithArgs(commons.StatusLoanChargedOff, data.WriteOffReason, "2024-08-24 10:00:00", data.WrittenOffInterest, data.WrittenOffPrincipal, commons.StatusLoanNew, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		written, err := repo.WriteOff(context.Background(), 1, data)
+
+		assert.NoError(t, err)
+		assert.True(t, written)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already written off", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		written, err := repo.WriteOff(context.Background(), 1, data)
+
+		assert.NoError(t, err)
+		assert.False(t, written)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET")).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		_, err := repo.WriteOff(context.Background(), 1, data)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLoanRepository_AddRecovery(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `recovered_amount`=recovered_amount + ? WHERE `id` = ?")).
+			WithArgs(500.0, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.AddRecovery(context.Background(), 1, 500.0)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `recovered_amount`=recovered_amount + ? WHERE `id` = ?")).
+			WithArgs(500.0, 1).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		err := repo.AddRecovery(context.Background(), 1, 500.0)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLoanRepository_Close(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	data := entity.LoanEntity{
+		Status:       commons.StatusLoanCancelled,
+		ClosedAt:     time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC),
+		ClosedReason: "withdraw in cooling off period",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `closed_at`=?,`closed_reason`=?,`status`=? WHERE status = ? AND `id` = ?")).
+			WithArgs("2024-08-24 10:00:00", data.ClosedReason, commons.StatusLoanCancelled, commons.StatusLoanNew, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		closed, err := repo.Close(context.Background(), 1, data)
+
+		assert.NoError(t, err)
+		assert.True(t, closed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		closed, err := repo.Close(context.Background(), 1, data)
+
+		assert.NoError(t, err)
+		assert.False(t, closed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET")).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		_, err := repo.Close(context.Background(), 1, data)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLoanRepository_CountByUsernameAndStatus(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `loan` WHERE username = ? AND status = ?")).
+			WithArgs("user123", commons.StatusLoanClosed).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+
+		count, err := repo.CountByUsernameAndStatus(context.Background(), "user123", commons.StatusLoanClosed)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `loan` WHERE username = ? AND status = ?")).
+			WithArgs("user123", commons.StatusLoanClosed).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.CountByUsernameAndStatus(context.Background(), "user123", commons.StatusLoanClosed)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoanRepository_SumOriginationFeeTax(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT currency, COALESCE(SUM(origination_fee_tax), 0) AS tax_amount FROM `loan` WHERE created_at >= ? AND created_at < ? AND status <> ? GROUP BY `currency`")).
+			WithArgs("2024-01-01 00:00:00", "2024-02-01 00:00:00", commons.StatusLoanCancelled).
+			WillReturnRows(sqlmock.NewRows([]string{"currency", "tax_amount"}).AddRow("IDR", 550).AddRow("USD", 1.1))
+
+		results, err := repo.SumOriginationFeeTax(context.Background(), from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TaxSummaryEntity{
+			{Currency: "IDR", TaxAmount: 550},
+			{Currency: "USD", TaxAmount: 1.1},
+		}, results)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT currency, COALESCE(SUM(origination_fee_tax), 0) AS tax_amount FROM `loan` WHERE created_at >= ? AND created_at < ? AND status <> ? GROUP BY `currency`")).
+			WithArgs("2024-01-01 00:00:00", "2024-02-01 00:00:00", commons.StatusLoanCancelled).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.SumOriginationFeeTax(context.Background(), from, to)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoanRepository_GetPortfolioByCurrency(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"currency", "loan_count", "amount", "paid_amount"}).
+			AddRow("IDR", 2, 110000000, 5500000).
+			AddRow("USD", 1, 1100.50, 0)
+
+		mock.ExpectQuery("SELECT loan.currency AS currency, COUNT\\(\\*\\) AS loan_count").
+			WithArgs(commons.StatusPayLoanPayed, commons.StatusLoanNew).
+			WillReturnRows(rows)
+
+		results, err := repo.GetPortfolioByCurrency(context.Background(), commons.StatusLoanNew)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "IDR", results[0].Currency)
+		assert.Equal(t, int64(2), results[0].LoanCount)
+		assert.Equal(t, float64(5500000), results[0].PaidAmount)
+		assert.Equal(t, 1100.50, results[1].Amount)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT loan.currency AS currency, COUNT\\(\\*\\) AS loan_count").
+			WithArgs(commons.StatusPayLoanPayed, commons.StatusLoanNew).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetPortfolioByCurrency(context.Background(), commons.StatusLoanNew)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoanRepository_ListByUsername(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+
+	t.Run("success first page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "username", "amount", "status", "created_at"}).
+			AddRow(1, "user123", 1100.0, commons.StatusLoanClosed, "2024-01-02 10:00:00").
+			AddRow(2, "user123", 2200.0, commons.StatusLoanNew, "2024-06-02 10:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE username = ? AND status IN (?,?) AND created_at >= ? ORDER BY created_at ASC, id ASC LIMIT ?")).
+			WithArgs("user123", commons.StatusLoanNew, commons.StatusLoanClosed, "2024-01-01 00:00:00", 21).
+			WillReturnRows(rows)
+
+		results, err := repo.ListByUsername(context.Background(), entity.HistoryFilter{
+			Username: "user123",
+			Statuses: []int{commons.StatusLoanNew, commons.StatusLoanClosed},
+			From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Limit:    21,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, 1, results[0].Id)
+	})
+
+	t.Run("success next page descending", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "username", "amount", "status", "created_at"}).
+			AddRow(1, "user123", 1100.0, commons.StatusLoanClosed, "2024-01-02 10:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE username = ? AND ((created_at < ? OR (created_at = ? AND id < ?))) ORDER BY created_at DESC, id DESC LIMIT ?")).
+			WithArgs("user123", "2024-06-02 10:00:00", "2024-06-02 10:00:00", 2, 21).
+			WillReturnRows(rows)
+
+		results, err := repo.ListByUsername(context.Background(), entity.HistoryFilter{
+			Username:   "user123",
+			After:      &entity.HistoryCursor{Time: time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC), Id: 2},
+			Limit:      21,
+			Descending: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE username = ?")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.ListByUsername(context.Background(), entity.HistoryFilter{Username: "user123", Limit: 21})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoanRepository_StreamLoans(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRepository(db)
+	filter := entity.ExportFilter{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "username", "amount", "status", "created_at", "currency"}).
+			AddRow(1, "user123", 1100.0, commons.StatusLoanClosed, "2024-01-02 10:00:00", "IDR").
+			AddRow(2, "user456", 2200.0, commons.StatusLoanNew, "2024-01-03 10:00:00", "USD")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan` WHERE created_at >= ? AND created_at < ? ORDER BY id")).
+			WithArgs("2024-01-01 00:00:00", "2024-02-01 00:00:00").
+			WillReturnRows(rows)
+
+		results := []entity.LoanEntity{}
+		err := repo.StreamLoans(context.Background(), filter, func(data entity.LoanEntity) error {
+			results = append(results, data)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "user456", results[1].Username)
+		assert.Equal(t, "USD", results[1].Currency)
+	})
+
+	t.Run("stop on error of fn", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "username"}).
+			AddRow(1, "user123").
+			AddRow(2, "user456")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan`")).
+			WillReturnRows(rows)
+
+		count := 0
+		err := repo.StreamLoans(context.Background(), entity.ExportFilter{}, func(data entity.LoanEntity) error {
+			count++
+			return errors.New("closed")
+		})
+
+		assert.EqualError(t, err, "closed")
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan`")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		err := repo.StreamLoans(context.Background(), filter, func(data entity.LoanEntity) error {
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type ILoanRestructureRepository interface {
+	Create(ctx context.Context, data entity.LoanRestructureEntity) (entity.LoanRestructureEntity, error)
+	GetByLoanId(ctx context.Context, loanId int) ([]entity.LoanRestructureEntity, error)
+}
+
+type LoanRestructureRepository struct {
+	DB *gorm.DB
+}
+
+func NewLoanRestructureRepository(DB *gorm.DB) ILoanRestructureRepository {
+	return &LoanRestructureRepository{
+		DB: DB,
+	}
+}
+
+func (lrr *LoanRestructureRepository) Create(ctx context.Context, data entity.LoanRestructureEntity) (entity.LoanRestructureEntity, error) {
+	model := models.LoanRestructureModel{
+		LoanId:            data.LoanId,
+		Outstanding:       data.Outstanding,
+		Arrears:           data.Arrears,
+		Tenor:             data.Tenor,
+		HolidayPeriods:    data.HolidayPeriods,
+		CapitalizeArrears: data.CapitalizeArrears,
+		CreatedAt:         data.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if response := lrr.DB.Table("loan_restructure").Create(&model); response.Error != nil {
+		return entity.LoanRestructureEntity{}, response.Error
+	}
+
+	return convertModelToEntityLoanRestructure(model), nil
+}
+
+func (lrr *LoanRestructureRepository) GetByLoanId(ctx context.Context, loanId int) ([]entity.LoanRestructureEntity, error) {
+	models := []models.LoanRestructureModel{}
+
+	if response := lrr.DB.Table("loan_restructure").Where("loan_id = ?", loanId).Order("id").Find(&models); response.Error != nil {
+		return []entity.LoanRestructureEntity{}, response.Error
+	}
+
+	result := []entity.LoanRestructureEntity{}
+	for _, model := range models {
+		result = append(result, convertModelToEntityLoanRestructure(model))
+	}
+
+	return result, nil
+}
+
+func convertModelToEntityLoanRestructure(model models.LoanRestructureModel) entity.LoanRestructureEntity {
+	createdAt, _ := time.Parse("2006-01-02 15:04:05", model.CreatedAt)
+
+	return entity.LoanRestructureEntity{
+		Id:                model.Id,
+		LoanId:            model.LoanId,
+		Outstanding:       model.Outstanding,
+		Arrears:           model.Arrears,
+		Tenor:             model.Tenor,
+		HolidayPeriods:    model.HolidayPeriods,
+		CapitalizeArrears: model.CapitalizeArrears,
+		CreatedAt:         createdAt,
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestLoanRestructureRepository_Create(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRestructureRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		data := entity.LoanRestructureEntity{
+			LoanId:            1,
+			Outstanding:       1000.0,
+			Arrears:           200.0,
+			Tenor:             10,
+			HolidayPeriods:    2,
+			CapitalizeArrears: true,
+			CreatedAt:         time.Now(),
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `loan_restructure` (`loan_id`,`outstanding`,`arrears`,`tenor`,`holiday_periods`,`capitalize_arrears`,`created_at`) VALUES (?,?,?,?,?,?,?)")).
+			WithArgs(data.LoanId, data.Outstanding, data.Arrears, data.Tenor, data.HolidayPeriods, data.CapitalizeArrears, data.CreatedAt.Format("2006-01-02 15:04:05")).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		result, err := repo.Create(context.Background(), data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Id)
+		assert.Equal(t, data.Tenor, result.Tenor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		data := entity.LoanRestructureEntity{
+			LoanId:    1,
+			CreatedAt: time.Now(),
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `loan_restructure`")).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		_, err := repo.Create(context.Background(), data)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLoanRestructureRepository_GetByLoanId(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLoanRestructureRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "outstanding", "arrears", "tenor", "holiday_periods", "capitalize_arrears", "created_at"}).
+			AddRow(1, 1, 1000.0, 200.0, 10, 2, true, "2023-08-24 10:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan_restructure` WHERE loan_id = ? ORDER BY id")).
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		results, err := repo.GetByLoanId(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.True(t, results[0].CapitalizeArrears)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `loan_restructure` WHERE loan_id = ? ORDER BY id")).
+			WithArgs(1).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetByLoanId(context.Background(), 1)
+
+		assert.Error(t, err)
+	})
+}
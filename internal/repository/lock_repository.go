@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ILockRepository interface {
+	Acquire(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name string, owner string) error
+}
+
+type LockRepository struct {
+	DB *gorm.DB
+}
+
+func NewLockRepository(DB *gorm.DB) ILockRepository {
+	return &LockRepository{
+		DB: DB,
+	}
+}
+
+// Acquire take lease of lock for ttl, or renew it when owner already hold it. Lease of other owner is
+// only taken over after expired. Expiry use database clock so clock of instances not need to be in sync
+func (lr *LockRepository) Acquire(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error) {
+	seconds := int(math.Ceil(ttl.Seconds()))
+
+	// owner is assigned first, so expires_at is extended when lock is ours after the owner assignment
+	if response := lr.DB.Exec(`INSERT INTO locks (name, owner, expires_at) VALUES (?, ?, NOW() + INTERVAL ? SECOND)
+		ON DUPLICATE KEY UPDATE
+		owner = IF(owner = VALUES(owner) OR expires_at < NOW(), VALUES(owner), owner),
+		expires_at = IF(owner = VALUES(owner), VALUES(expires_at), expires_at)`,
+		name, owner, seconds); response.Error != nil {
+		return false, response.Error
+	}
+
+	var holder string
+	if response := lr.DB.Table("locks").Select("owner").Where("name = ?", name).Scan(&holder); response.Error != nil {
+		return false, response.Error
+	}
+
+	return holder == owner, nil
+}
+
+// Release give up lease so other instance can take it without waiting expiry
+func (lr *LockRepository) Release(ctx context.Context, name string, owner string) error {
+	if response := lr.DB.Exec("DELETE FROM locks WHERE name = ? AND owner = ?", name, owner); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
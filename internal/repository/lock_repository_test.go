@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestLockRepository_Acquire(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLockRepository(db)
+
+	t.Run("acquired", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO locks (name, owner, expires_at) VALUES (?, ?, NOW() + INTERVAL ? SECOND)")).
+			WithArgs("scheduler", "host-1", 30).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT owner FROM `locks` WHERE name = ?")).
+			WithArgs("scheduler").
+			WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow("host-1"))
+
+		acquired, err := repo.Acquire(context.Background(), "scheduler", "host-1", 30*time.Second)
+
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("held by other", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO locks")).
+			WithArgs("scheduler", "host-2", 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT owner FROM `locks` WHERE name = ?")).
+			WithArgs("scheduler").
+			WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow("host-1"))
+
+		acquired, err := repo.Acquire(context.Background(), "scheduler", "host-2", 1500*time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO locks")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.Acquire(context.Background(), "scheduler", "host-1", 30*time.Second)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLockRepository_Release(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewLockRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM locks WHERE name = ? AND owner = ?")).
+			WithArgs("scheduler", "host-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.Release(context.Background(), "scheduler", "host-1"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM locks")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		assert.Error(t, repo.Release(context.Background(), "scheduler", "host-1"))
+	})
+}
@@ -0,0 +1,8 @@
+package models
+
+type HolidayModel struct {
+	Id      int    `db:"id"`
+	Country string `db:"country"`
+	Date    string `db:"date"`
+	Name    string `db:"name"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type JobRunModel struct {
+	Id          int     `db:"id"`
+	JobName     string  `db:"job_name"`
+	ScheduledAt string  `db:"scheduled_at"`
+	StartedAt   string  `db:"started_at"`
+	FinishedAt  *string `db:"finished_at"`
+	Status      int     `db:"status"`
+	Error       *string `db:"error"`
+}
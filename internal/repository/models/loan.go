@@ -1,9 +1,31 @@
 package models
 
 type LoanModel struct {
-	Id        int     `db:"id"`
-	Username  string  `db:"username"`
-	Amount    float64 `db:"amount"`
-	CreatedAt string  `db:"created_at"`
-	Status    int     `db:"status"`
+	Id          int     `db:"id"`
+	Username    string  `db:"username"`
+	ProductCode string  `db:"product_code"`
+	Amount      float64 `db:"amount"`
+	CreatedAt   string  `db:"created_at"`
+	Status      int     `db:"status"`
+
+	WrittenOffPrincipal float64 `db:"written_off_principal"`
+	WrittenOffInterest  float64 `db:"written_off_interest"`
+	WrittenOffAt        *string `db:"written_off_at"`
+	WriteOffReason      string  `db:"write_off_reason"`
+	RecoveredAmount     float64 `db:"recovered_amount"`
+
+	ClosedAt       *string `db:"closed_at"`
+	ClosedReason   string  `db:"closed_reason"`
+	RefinancedFrom int     `db:"refinanced_from"`
+
+	OriginationFee  float64 `db:"origination_fee"`
+	ProcessingFee   float64 `db:"processing_fee"`
+	DisbursedAmount float64 `db:"disbursed_amount"`
+
+	OriginationFeeTax float64 `db:"origination_fee_tax"`
+
+	PromoCode      string  `db:"promo_code"`
+	DiscountAmount float64 `db:"discount_amount"`
+
+	Currency string `db:"currency"`
 }
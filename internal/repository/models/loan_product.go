@@ -0,0 +1,24 @@
+package models
+
+type LoanProductModel struct {
+	Code           string  `db:"code"`
+	Name           string  `db:"name"`
+	Interest       float64 `db:"interest"`
+	Tenor          int     `db:"tenor"`
+	GracePeriods   int     `db:"grace_periods"`
+	GraceInterest  int     `db:"grace_interest"`
+	Country        string  `db:"country"`
+	RollConvention int     `db:"roll_convention"`
+	WriteOffDays   int     `db:"write_off_days"`
+	CoolingOffDays int     `db:"cooling_off_days"`
+
+	OriginationFee     float64 `db:"origination_fee"`
+	OriginationFeeType int     `db:"origination_fee_type"`
+	AdminFee           float64 `db:"admin_fee"`
+	ProcessingFee      float64 `db:"processing_fee"`
+
+	Currency string `db:"currency"`
+
+	MinAmount float64 `db:"min_amount"`
+	MaxAmount float64 `db:"max_amount"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type LoanRestructureModel struct {
+	Id                int     `db:"id"`
+	LoanId            int     `db:"loan_id"`
+	Outstanding       float64 `db:"outstanding"`
+	Arrears           float64 `db:"arrears"`
+	Tenor             int     `db:"tenor"`
+	HolidayPeriods    int     `db:"holiday_periods"`
+	CapitalizeArrears bool    `db:"capitalize_arrears"`
+	CreatedAt         string  `db:"created_at"`
+}
@@ -6,4 +6,25 @@ type PayLoanModel struct {
 	Amount    float64 `db:"amount"`
 	CreatedAt string  `db:"created_at"`
 	Status    int     `db:"status"`
+	FeeAmount float64 `db:"fee_amount"`
+
+	TaxAmount            float64 `db:"tax_amount"`
+	WithholdingTaxAmount float64 `db:"withholding_tax_amount"`
+
+	DiscountAmount float64 `db:"discount_amount"`
+
+	PaidAt     *string `db:"paid_at"`
+	PaidAmount float64 `db:"paid_amount"`
+}
+
+type InstallmentRowModel struct {
+	PayLoanModel
+	Username string `db:"username"`
+	Currency string `db:"currency"`
+}
+
+type OverdueModel struct {
+	LoanId        int    `db:"loan_id"`
+	OverdueCount  int    `db:"overdue_count"`
+	OldestDueDate string `db:"oldest_due_date"`
 }
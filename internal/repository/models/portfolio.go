@@ -0,0 +1,8 @@
+package models
+
+type PortfolioModel struct {
+	Currency   string  `db:"currency"`
+	LoanCount  int64   `db:"loan_count"`
+	Amount     float64 `db:"amount"`
+	PaidAmount float64 `db:"paid_amount"`
+}
@@ -0,0 +1,16 @@
+package models
+
+type PromoModel struct {
+	Code            string  `db:"code"`
+	Name            string  `db:"name"`
+	DiscountType    int     `db:"discount_type"`
+	DiscountValue   float64 `db:"discount_value"`
+	ValidFrom       *string `db:"valid_from"`
+	ValidTo         *string `db:"valid_to"`
+	UsageLimit      int     `db:"usage_limit"`
+	UsageCount      int     `db:"usage_count"`
+	ProductCode     string  `db:"product_code"`
+	MinAmount       float64 `db:"min_amount"`
+	MaxAmount       float64 `db:"max_amount"`
+	NewBorrowerOnly bool    `db:"new_borrower_only"`
+}
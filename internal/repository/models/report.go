@@ -0,0 +1,32 @@
+package models
+
+type ExposureModel struct {
+	LoanId        int     `db:"loan_id"`
+	Username      string  `db:"username"`
+	Currency      string  `db:"currency"`
+	ProductCode   string  `db:"product_code"`
+	Outstanding   float64 `db:"outstanding"`
+	OverdueCount  int     `db:"overdue_count"`
+	OldestDueDate string  `db:"oldest_due_date"`
+}
+
+type CollectionModel struct {
+	Currency string  `db:"currency"`
+	Count    int     `db:"count"`
+	Amount   float64 `db:"amount"`
+}
+
+type ReportSnapshotModel struct {
+	ReportDate          string  `db:"report_date"`
+	Currency            string  `db:"currency"`
+	LoanCount           int     `db:"loan_count"`
+	Outstanding         float64 `db:"outstanding"`
+	Par30Amount         float64 `db:"par30_amount"`
+	Par90Amount         float64 `db:"par90_amount"`
+	Par30               float64 `db:"par30"`
+	Par90               float64 `db:"par90"`
+	DelinquentBorrowers int     `db:"delinquent_borrowers"`
+	CollectionCount     int     `db:"collection_count"`
+	Collections         float64 `db:"collections"`
+	CreatedAt           string  `db:"created_at"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type StatementModel struct {
+	Id          int    `db:"id"`
+	LoanId      int    `db:"loan_id"`
+	PeriodStart string `db:"period_start"`
+	PeriodEnd   string `db:"period_end"`
+	Format      string `db:"format"`
+	Content     []byte `db:"content"`
+	CreatedAt   string `db:"created_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type TaxRuleModel struct {
+	Id      int     `db:"id"`
+	Country string  `db:"country"`
+	Type    int     `db:"type"`
+	Rate    float64 `db:"rate"`
+	Name    string  `db:"name"`
+}
+
+type TaxSummaryModel struct {
+	Currency             string  `db:"currency"`
+	TaxAmount            float64 `db:"tax_amount"`
+	WithholdingTaxAmount float64 `db:"withholding_tax_amount"`
+}
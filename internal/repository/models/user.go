@@ -1,6 +1,15 @@
 package models
 
 type UserModel struct {
-	Username string `db:"username"`
-	Status   int    `db:"status"`
+	Username       string  `db:"username"`
+	FullName       string  `db:"full_name"`
+	NationalId     string  `db:"national_id"`
+	Phone          string  `db:"phone"`
+	Email          string  `db:"email"`
+	Address        string  `db:"address"`
+	DateOfBirth    *string `db:"date_of_birth"`
+	KycStatus      int     `db:"kyc_status"`
+	KycSubmittedAt *string `db:"kyc_submitted_at"`
+	KycVerifiedAt  *string `db:"kyc_verified_at"`
+	Status         int     `db:"status"`
 }
@@ -13,8 +13,21 @@ import (
 type IPayLoanRepository interface {
 	GetPayLoanByLoanId(ctx context.Context, loandId int) ([]entity.PayLoanEntity, error)
 	GetInSpecificTimeAndStatus(ctx context.Context, loanId int, timeNow time.Time) ([]entity.PayLoanEntity, error)
+	GetOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.PayLoanEntity, error)
+	GetUnpaidAtByLoanIds(ctx context.Context, loanIds []int, asOf time.Time) ([]entity.PayLoanEntity, error)
+	CountOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.OverdueEntity, error)
+	GetFullyPaidLoanIds(ctx context.Context, loanIds []int) ([]int, error)
 	BatchInsert(ctx context.Context, datas []entity.PayLoanEntity) error
 	Update(ctx context.Context, id int, data entity.PayLoanEntity) error
+	UpdateStatusByLoanId(ctx context.Context, loanId int, fromStatus int, toStatus int) error
+	Reschedule(ctx context.Context, datas []entity.PayLoanEntity) error
+	SumTax(ctx context.Context, from time.Time, to time.Time, status int) ([]entity.TaxSummaryEntity, error)
+	ListByUsername(ctx context.Context, filter entity.HistoryFilter) ([]entity.PayLoanEntity, error)
+	GetExposures(ctx context.Context, asOf time.Time) ([]entity.ExposureEntity, error)
+	SumCollections(ctx context.Context, from time.Time, to time.Time) ([]entity.CollectionEntity, error)
+	StreamExposures(ctx context.Context, asOf time.Time, fn func(data entity.ExposureEntity) error) error
+	StreamSchedules(ctx context.Context, filter entity.ExportFilter, fn func(data entity.InstallmentRowEntity) error) error
+	StreamPayments(ctx context.Context, filter entity.ExportFilter, fn func(data entity.InstallmentRowEntity) error) error
 }
 
 type PayLoanRepository struct {
@@ -41,6 +54,77 @@ func (plr *PayLoanRepository) GetInSpecificTimeAndStatus(ctx context.Context, lo
 	return convertBulkModelToEntitiesPayLoan(models), nil
 }
 
+// GetOverdueByLoanIds return unpaid installment due before timeNow of every loan in loanIds with one query
+func (plr *PayLoanRepository) GetOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.PayLoanEntity, error) {
+	models := []models.PayLoanModel{}
+
+	if len(loanIds) == 0 {
+		return []entity.PayLoanEntity{}, nil
+	}
+
+	if response := plr.DB.Table("pay_loan").
+		Where("loan_id IN ?", loanIds).
+		Where("status = ?", commons.StatusPayLoanUnpayed).
+		Where("created_at < ?", timeNow.Format("2006-01-02 15:04:05")).
+		Order("loan_id ASC, created_at ASC").
+		Find(&models); response.Error != nil {
+		return []entity.PayLoanEntity{}, response.Error
+	}
+
+	return convertBulkModelToEntitiesPayLoan(models), nil
+}
+
+// CountOverdueByLoanIds count unpaid installment due before timeNow grouped by loan,
+// loan without overdue installment is not returned
+func (plr *PayLoanRepository) CountOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.OverdueEntity, error) {
+	models := []models.OverdueModel{}
+
+	if len(loanIds) == 0 {
+		return []entity.OverdueEntity{}, nil
+	}
+
+	if response := plr.DB.Raw(`SELECT loan_id, COUNT(*) AS overdue_count, MIN(created_at) AS oldest_due_date
+		FROM pay_loan
+		WHERE loan_id IN ? AND status = ? AND created_at < ?
+		GROUP BY loan_id`,
+		loanIds, commons.StatusPayLoanUnpayed, timeNow.Format("2006-01-02 15:04:05")).Scan(&models); response.Error != nil {
+		return []entity.OverdueEntity{}, response.Error
+	}
+
+	result := []entity.OverdueEntity{}
+	for _, model := range models {
+		oldestDueDate, _ := time.Parse("2006-01-02 15:04:05", model.OldestDueDate)
+
+		result = append(result, entity.OverdueEntity{
+			LoanId:        model.LoanId,
+			OverdueCount:  model.OverdueCount,
+			OldestDueDate: oldestDueDate,
+		})
+	}
+
+	return result, nil
+}
+
+// GetFullyPaidLoanIds return loan in loanIds that has installment but none of them unpaid
+func (plr *PayLoanRepository) GetFullyPaidLoanIds(ctx context.Context, loanIds []int) ([]int, error) {
+	result := []int{}
+
+	if len(loanIds) == 0 {
+		return result, nil
+	}
+
+	if response := plr.DB.Raw(`SELECT loan_id
+		FROM pay_loan
+		WHERE loan_id IN ?
+		GROUP BY loan_id
+		HAVING SUM(status = ?) = 0`,
+		loanIds, commons.StatusPayLoanUnpayed).Scan(&result); response.Error != nil {
+		return []int{}, response.Error
+	}
+
+	return result, nil
+}
+
 func (plr *PayLoanRepository) BatchInsert(ctx context.Context, datas []entity.PayLoanEntity) error {
 	models := convertBulkEntityToModelsPayLoan(datas)
 
@@ -55,15 +139,72 @@ func (plr *PayLoanRepository) Update(ctx context.Context, id int, data entity.Pa
 	model := models.PayLoanModel{
 		Id: id,
 	}
-	if response := plr.DB.Table("pay_loan").Model(&model).Updates(map[string]interface{}{
+	values := map[string]interface{}{
 		"status": data.Status,
-	}); response.Error != nil {
+	}
+
+	// record payment when installment is paid
+	if !data.PaidAt.IsZero() {
+		values["paid_at"] = data.PaidAt.Format("2006-01-02 15:04:05")
+		values["paid_amount"] = data.PaidAmount
+	}
+
+	if response := plr.DB.Table("pay_loan").Model(&model).Updates(values); response.Error != nil {
 		return response.Error
 	}
 
 	return nil
 }
 
+func (plr *PayLoanRepository) UpdateStatusByLoanId(ctx context.Context, loanId int, fromStatus int, toStatus int) error {
+	if response := plr.DB.Table("pay_loan").
+		Where("loan_id = ?", loanId).
+		Where("status = ?", fromStatus).
+		Update("status", toStatus); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+// Reschedule move due date and amount of existing installments
+func (plr *PayLoanRepository) Reschedule(ctx context.Context, datas []entity.PayLoanEntity) error {
+	return plr.DB.Transaction(func(tx *gorm.DB) error {
+		for _, data := range datas {
+			model := models.PayLoanModel{
+				Id: data.Id,
+			}
+
+			if response := tx.Table("pay_loan").Model(&model).Updates(map[string]interface{}{
+				"amount":     data.Amount,
+				"created_at": data.CreatedAt.Format("2006-01-02 15:04:05"),
+			}); response.Error != nil {
+				return response.Error
+			}
+		}
+
+		return nil
+	})
+}
+
+// SumTax return tax of installments with status that due in date range grouped by currency of the loan
+func (plr *PayLoanRepository) SumTax(ctx context.Context, from time.Time, to time.Time, status int) ([]entity.TaxSummaryEntity, error) {
+	models := []models.TaxSummaryModel{}
+
+	if response := plr.DB.Table("pay_loan").
+		Select("loan.currency AS currency, COALESCE(SUM(pay_loan.tax_amount), 0) AS tax_amount, COALESCE(SUM(pay_loan.withholding_tax_amount), 0) AS withholding_tax_amount").
+		Joins("JOIN loan ON loan.id = pay_loan.loan_id").
+		Where("pay_loan.created_at >= ?", from.Format("2006-01-02 15:04:05")).
+		Where("pay_loan.created_at < ?", to.Format("2006-01-02 15:04:05")).
+		Where("pay_loan.status = ?", status).
+		Group("loan.currency").
+		Scan(&models); response.Error != nil {
+		return []entity.TaxSummaryEntity{}, response.Error
+	}
+
+	return convertModelsToEntitiesTaxSummary(models), nil
+}
+
 func (plr *PayLoanRepository) GetPayLoanByLoanId(ctx context.Context, loandId int) ([]entity.PayLoanEntity, error) {
 	models := []models.PayLoanModel{}
 
@@ -74,6 +215,152 @@ func (plr *PayLoanRepository) GetPayLoanByLoanId(ctx context.Context, loandId in
 	return convertBulkModelToEntitiesPayLoan(models), nil
 }
 
+// ListByUsername return page of payment from every loan of user ordered by paid time, only paid installment has paid time
+// so other status is never returned
+func (plr *PayLoanRepository) ListByUsername(ctx context.Context, filter entity.HistoryFilter) ([]entity.PayLoanEntity, error) {
+	models := []models.PayLoanModel{}
+
+	query := plr.DB.Table("pay_loan").
+		Select("pay_loan.*").
+		Joins("JOIN loan ON loan.id = pay_loan.loan_id").
+		Where("loan.username = ?", filter.Username).
+		Where("pay_loan.status = ?", commons.StatusPayLoanPayed)
+	if response := applyHistoryFilter(query, filter, "pay_loan.status", "pay_loan.paid_at", "pay_loan.id").Find(&models); response.Error != nil {
+		return []entity.PayLoanEntity{}, response.Error
+	}
+
+	return convertBulkModelToEntitiesPayLoan(models), nil
+}
+
+// GetUnpaidAtByLoanIds return installment still unpaid at asOf of every loan in loanIds ordered by due date,
+// installment paid after asOf is counted as unpaid like GetExposures
+func (plr *PayLoanRepository) GetUnpaidAtByLoanIds(ctx context.Context, loanIds []int, asOf time.Time) ([]entity.PayLoanEntity, error) {
+	models := []models.PayLoanModel{}
+
+	if len(loanIds) == 0 {
+		return []entity.PayLoanEntity{}, nil
+	}
+
+	at := asOf.Format("2006-01-02 15:04:05")
+	if response := plr.DB.Table("pay_loan").
+		Where("loan_id IN ?", loanIds).
+		Where("status = ? OR (status = ? AND paid_at >= ?)", commons.StatusPayLoanUnpayed, commons.StatusPayLoanPayed, at).
+		Order("loan_id ASC, created_at ASC").
+		Find(&models); response.Error != nil {
+		return []entity.PayLoanEntity{}, response.Error
+	}
+
+	return convertBulkModelToEntitiesPayLoan(models), nil
+}
+
+// GetExposures sum principal of installment still unpaid at asOf per loan booked before asOf, installment paid after asOf
+// is counted as unpaid so report of past date is not changed by later payment. Interest is taken out of installment
+// amount by interest of loan product, so portfolio at risk is measured on principal
+func (plr *PayLoanRepository) GetExposures(ctx context.Context, asOf time.Time) ([]entity.ExposureEntity, error) {
+	result := []entity.ExposureEntity{}
+
+	err := plr.StreamExposures(ctx, asOf, func(data entity.ExposureEntity) error {
+		result = append(result, data)
+		return nil
+	})
+	if err != nil {
+		return []entity.ExposureEntity{}, err
+	}
+
+	return result, nil
+}
+
+// StreamExposures pass exposure of GetExposures ordered by loan id to fn one by one
+func (plr *PayLoanRepository) StreamExposures(ctx context.Context, asOf time.Time, fn func(data entity.ExposureEntity) error) error {
+	at := asOf.Format("2006-01-02 15:04:05")
+
+	query := plr.DB.Raw(`SELECT loan.id AS loan_id, loan.username AS username, loan.currency AS currency, loan.product_code AS product_code,
+		SUM(pay_loan.amount * 100 / (100 + COALESCE(loan_product.interest, 0))) AS outstanding,
+		SUM(CASE WHEN pay_loan.created_at < ? THEN 1 ELSE 0 END) AS overdue_count,
+		MIN(pay_loan.created_at) AS oldest_due_date
+		FROM pay_loan
+		JOIN loan ON loan.id = pay_loan.loan_id
+		LEFT JOIN loan_product ON loan_product.code = COALESCE(NULLIF(loan.product_code, ''), ?)
+		WHERE loan.created_at < ?
+		AND (pay_loan.status = ? OR (pay_loan.status = ? AND pay_loan.paid_at >= ?))
+		GROUP BY loan.id, loan.username, loan.currency, loan.product_code
+		ORDER BY loan.id`,
+		at, commons.DefaultProductCode, at, commons.StatusPayLoanUnpayed, commons.StatusPayLoanPayed, at)
+
+	return streamRows(query, func(model models.ExposureModel) error {
+		oldestDueDate, _ := time.Parse("2006-01-02 15:04:05", model.OldestDueDate)
+
+		return fn(entity.ExposureEntity{
+			LoanId:        model.LoanId,
+			Username:      model.Username,
+			Currency:      model.Currency,
+			ProductCode:   model.ProductCode,
+			Outstanding:   model.Outstanding,
+			OverdueCount:  model.OverdueCount,
+			OldestDueDate: oldestDueDate,
+		})
+	})
+}
+
+// StreamSchedules pass installment due in time range ordered by loan id and due date to fn one by one
+func (plr *PayLoanRepository) StreamSchedules(ctx context.Context, filter entity.ExportFilter, fn func(data entity.InstallmentRowEntity) error) error {
+	query := applyExportFilter(plr.installmentRows(), filter, "pay_loan.created_at").
+		Order("pay_loan.loan_id, pay_loan.created_at, pay_loan.id")
+
+	return streamRows(query, func(model models.InstallmentRowModel) error {
+		return fn(convertModelToEntityInstallmentRow(model))
+	})
+}
+
+// StreamPayments pass installment paid in time range ordered by paid time to fn one by one
+func (plr *PayLoanRepository) StreamPayments(ctx context.Context, filter entity.ExportFilter, fn func(data entity.InstallmentRowEntity) error) error {
+	query := applyExportFilter(plr.installmentRows().Where("pay_loan.status = ?", commons.StatusPayLoanPayed), filter, "pay_loan.paid_at").
+		Order("pay_loan.paid_at, pay_loan.id")
+
+	return streamRows(query, func(model models.InstallmentRowModel) error {
+		return fn(convertModelToEntityInstallmentRow(model))
+	})
+}
+
+func (plr *PayLoanRepository) installmentRows() *gorm.DB {
+	return plr.DB.Table("pay_loan").
+		Select("pay_loan.*, loan.username, loan.currency").
+		Joins("JOIN loan ON loan.id = pay_loan.loan_id")
+}
+
+// SumCollections sum installment paid between from and to grouped by currency of loan
+func (plr *PayLoanRepository) SumCollections(ctx context.Context, from time.Time, to time.Time) ([]entity.CollectionEntity, error) {
+	models := []models.CollectionModel{}
+
+	if response := plr.DB.Raw(`SELECT loan.currency AS currency, COUNT(*) AS count, COALESCE(SUM(pay_loan.paid_amount), 0) AS amount
+		FROM pay_loan
+		JOIN loan ON loan.id = pay_loan.loan_id
+		WHERE pay_loan.status = ? AND pay_loan.paid_at >= ? AND pay_loan.paid_at < ?
+		GROUP BY loan.currency`,
+		commons.StatusPayLoanPayed, from.Format("2006-01-02 15:04:05"), to.Format("2006-01-02 15:04:05")).Scan(&models); response.Error != nil {
+		return []entity.CollectionEntity{}, response.Error
+	}
+
+	result := []entity.CollectionEntity{}
+	for _, model := range models {
+		result = append(result, entity.CollectionEntity{
+			Currency: model.Currency,
+			Count:    model.Count,
+			Amount:   model.Amount,
+		})
+	}
+
+	return result, nil
+}
+
+func convertModelToEntityInstallmentRow(model models.InstallmentRowModel) entity.InstallmentRowEntity {
+	return entity.InstallmentRowEntity{
+		PayLoan:  convertModelToEntityPayLoan(model.PayLoanModel),
+		Username: model.Username,
+		Currency: model.Currency,
+	}
+}
+
 func convertModelToEntityPayLoan(model models.PayLoanModel) entity.PayLoanEntity {
 	createdAt, _ := time.Parse("2006-01-02 15:04:05", model.CreatedAt)
 
@@ -83,6 +370,15 @@ func convertModelToEntityPayLoan(model models.PayLoanModel) entity.PayLoanEntity
 		Amount:    model.Amount,
 		Status:    model.Status,
 		CreatedAt: createdAt,
+		FeeAmount: model.FeeAmount,
+
+		TaxAmount:            model.TaxAmount,
+		WithholdingTaxAmount: model.WithholdingTaxAmount,
+
+		DiscountAmount: model.DiscountAmount,
+
+		PaidAt:     parseNullableTime("2006-01-02 15:04:05", model.PaidAt),
+		PaidAmount: model.PaidAmount,
 	}
 }
 
@@ -93,6 +389,15 @@ func convertEntityToModelPayLoan(entity entity.PayLoanEntity) models.PayLoanMode
 		Amount:    entity.Amount,
 		CreatedAt: entity.CreatedAt.Format("2006-01-02 15:04:05"),
 		Status:    entity.Status,
+		FeeAmount: entity.FeeAmount,
+
+		TaxAmount:            entity.TaxAmount,
+		WithholdingTaxAmount: entity.WithholdingTaxAmount,
+
+		DiscountAmount: entity.DiscountAmount,
+
+		PaidAt:     formatNullableTime("2006-01-02 15:04:05", entity.PaidAt),
+		PaidAmount: entity.PaidAmount,
 	}
 }
 
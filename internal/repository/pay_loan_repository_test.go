@@ -75,6 +75,141 @@ func TestPayLoanRepository_GetInSpecificTimeAndStatus(t *testing.T) {
 	})
 }
 
+func TestPayLoanRepository_GetOverdueByLoanIds(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	timeNow := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "amount", "status", "created_at"}).
+			AddRow(1, 7, 1000.0, commons.StatusPayLoanUnpayed, "2024-02-20 10:00:00").
+			AddRow(5, 8, 1000.0, commons.StatusPayLoanUnpayed, "2024-02-27 10:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `pay_loan` WHERE loan_id IN (?,?) AND status = ? AND created_at < ? ORDER BY loan_id ASC, created_at ASC")).
+			WithArgs(7, 8, commons.StatusPayLoanUnpayed, "2024-03-05 10:00:00").
+			WillReturnRows(rows)
+
+		results, err := repo.GetOverdueByLoanIds(context.Background(), []int{7, 8}, timeNow)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, 8, results[1].LoanId)
+	})
+
+	t.Run("no loan", func(t *testing.T) {
+		results, err := repo.GetOverdueByLoanIds(context.Background(), []int{}, timeNow)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `pay_loan` WHERE loan_id IN (?)")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetOverdueByLoanIds(context.Background(), []int{7}, timeNow)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_GetUnpaidAtByLoanIds(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	asOf := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "amount", "status", "created_at"}).
+			AddRow(1, 7, 1000.0, commons.StatusPayLoanPayed, "2024-02-24 10:00:00").
+			AddRow(2, 7, 1000.0, commons.StatusPayLoanUnpayed, "2024-03-02 10:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `pay_loan` WHERE loan_id IN (?,?) AND (status = ? OR (status = ? AND paid_at >= ?)) ORDER BY loan_id ASC, created_at ASC")).
+			WithArgs(7, 8, commons.StatusPayLoanUnpayed, commons.StatusPayLoanPayed, "2024-03-01 00:00:00").
+			WillReturnRows(rows)
+
+		results, err := repo.GetUnpaidAtByLoanIds(context.Background(), []int{7, 8}, asOf)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("no loan", func(t *testing.T) {
+		results, err := repo.GetUnpaidAtByLoanIds(context.Background(), []int{}, asOf)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `pay_loan` WHERE loan_id IN (?)")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetUnpaidAtByLoanIds(context.Background(), []int{7}, asOf)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_CountOverdueByLoanIds(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	timeNow := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"loan_id", "overdue_count", "oldest_due_date"}).
+			AddRow(7, 2, "2024-02-20 10:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT loan_id, COUNT(*) AS overdue_count, MIN(created_at) AS oldest_due_date")).
+			WithArgs(7, 8, commons.StatusPayLoanUnpayed, "2024-03-05 10:00:00").
+			WillReturnRows(rows)
+
+		results, err := repo.CountOverdueByLoanIds(context.Background(), []int{7, 8}, timeNow)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.OverdueEntity{
+			{LoanId: 7, OverdueCount: 2, OldestDueDate: time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC)},
+		}, results)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT loan_id, COUNT(*) AS overdue_count")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.CountOverdueByLoanIds(context.Background(), []int{7}, timeNow)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_GetFullyPaidLoanIds(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT loan_id FROM pay_loan WHERE loan_id IN (?,?,?) GROUP BY loan_id HAVING SUM(status = ?) = 0")).
+			WithArgs(7, 8, 9, commons.StatusPayLoanUnpayed).
+			WillReturnRows(sqlmock.NewRows([]string{"loan_id"}).AddRow(8).AddRow(9))
+
+		results, err := repo.GetFullyPaidLoanIds(context.Background(), []int{7, 8, 9})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{8, 9}, results)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT loan_id FROM pay_loan")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetFullyPaidLoanIds(context.Background(), []int{7})
+
+		assert.Error(t, err)
+	})
+}
+
 func TestPayLoanRepository_BatchInsert(t *testing.T) {
 	db, mock := setupTestDB(t)
 
@@ -86,8 +221,8 @@ func TestPayLoanRepository_BatchInsert(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `pay_loan` (`loan_id`,`amount`,`created_at`,`status`) VALUES (?,?,?,?)")).
-			WithArgs(1, 1000.0, sqlmock.AnyArg(), commons.StatusPayLoanUnpayed).
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `pay_loan` (`loan_id`,`amount`,`created_at`,`status`,`fee_amount`,`tax_amount`,`withholding_tax_amount`,`discount_amount`,`paid_at`,`paid_amount`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+			WithArgs(1, 1000.0, sqlmock.AnyArg(), commons.StatusPayLoanUnpayed, float64(0), float64(0), float64(0), float64(0), nil, float64(0)).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -103,8 +238,8 @@ func TestPayLoanRepository_BatchInsert(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `pay_loan` (`loan_id`,`amount`,`created_at`,`status`) VALUES (?,?,?,?)")).
-			WithArgs(1, 1000.0, sqlmock.AnyArg(), commons.StatusPayLoanUnpayed).
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `pay_loan` (`loan_id`,`amount`,`created_at`,`status`,`fee_amount`,`tax_amount`,`withholding_tax_amount`,`discount_amount`,`paid_at`,`paid_amount`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+			WithArgs(1, 1000.0, sqlmock.AnyArg(), commons.StatusPayLoanUnpayed, float64(0), float64(0), float64(0), float64(0), nil, float64(0)).
 			WillReturnError(gorm.ErrInvalidData)
 		mock.ExpectRollback()
 
@@ -135,6 +270,22 @@ func TestPayLoanRepository_Update(t *testing.T) {
 		mock.ExpectationsWereMet()
 	})
 
+	t.Run("success record payment", func(t *testing.T) {
+		paidAt := time.Date(2024, 8, 19, 10, 0, 0, 0, time.UTC)
+		data := entity.PayLoanEntity{Status: commons.StatusPayLoanPayed, PaidAt: paidAt, PaidAmount: 1100}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `pay_loan` SET `paid_amount`=?,`paid_at`=?,`status`=? WHERE `id` = ?")).
+			WithArgs(float64(1100), "2024-08-19 10:00:00", commons.StatusPayLoanPayed, 1).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(context.Background(), 1, data)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("error", func(t *testing.T) {
 		data := entity.PayLoanEntity{Status: commons.StatusPayLoanUnpayed}
 
@@ -159,8 +310,9 @@ func TestPayLoanRepository_GetPayLoanByLoanId(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		loanId := 1
 
-		rows := sqlmock.NewRows([]string{"id", "loan_id", "amount", "status", "created_at"}).
-			AddRow(1, loanId, 1000.0, commons.StatusPayLoanUnpayed, "2023-08-24 10:00:00")
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "amount", "status", "created_at", "paid_at", "paid_amount"}).
+			AddRow(1, loanId, 1000.0, commons.StatusPayLoanUnpayed, "2023-08-24 10:00:00", nil, 0.0).
+			AddRow(2, loanId, 1000.0, commons.StatusPayLoanPayed, "2023-08-17 10:00:00", "2023-08-16 09:30:00", 1000.0)
 
 		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `pay_loan` WHERE loan_id = ?")).
 			WithArgs(loanId).
@@ -169,9 +321,12 @@ func TestPayLoanRepository_GetPayLoanByLoanId(t *testing.T) {
 		results, err := repo.GetPayLoanByLoanId(context.Background(), loanId)
 
 		assert.NoError(t, err)
-		assert.Len(t, results, 1)
+		assert.Len(t, results, 2)
 		assert.Equal(t, loanId, results[0].LoanId)
 		assert.Equal(t, commons.StatusPayLoanUnpayed, results[0].Status)
+		assert.True(t, results[0].PaidAt.IsZero())
+		assert.Equal(t, time.Date(2023, 8, 16, 9, 30, 0, 0, time.UTC), results[1].PaidAt)
+		assert.Equal(t, float64(1000), results[1].PaidAmount)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -187,3 +342,292 @@ func TestPayLoanRepository_GetPayLoanByLoanId(t *testing.T) {
 		assert.Empty(t, results)
 	})
 }
+
+func TestPayLoanRepository_UpdateStatusByLoanId(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `pay_loan` SET `status`=? WHERE loan_id = ? AND status = ?")).
+			WithArgs(commons.StatusPayLoanSuperseded, 1, commons.StatusPayLoanUnpayed).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mock.ExpectCommit()
+
+		err := repo.UpdateStatusByLoanId(context.Background(), 1, commons.StatusPayLoanUnpayed, commons.StatusPayLoanSuperseded)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `pay_loan` SET `status`=? WHERE loan_id = ? AND status = ?")).
+			WithArgs(commons.StatusPayLoanSuperseded, 1, commons.StatusPayLoanUnpayed).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		err := repo.UpdateStatusByLoanId(context.Background(), 1, commons.StatusPayLoanUnpayed, commons.StatusPayLoanSuperseded)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPayLoanRepository_Reschedule(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+
+	dueDate := time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC)
+	data := []entity.PayLoanEntity{
+		{Id: 1, Amount: 1000.0, CreatedAt: dueDate},
+		{Id: 2, Amount: 1000.0, CreatedAt: dueDate.Add(commons.DifferentTime)},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `pay_loan` SET `amount`=?,`created_at`=? WHERE `id` = ?")).
+			WithArgs(1000.0, "2024-08-24 10:00:00", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `pay_loan` SET `amount`=?,`created_at`=? WHERE `id` = ?")).
+			WithArgs(1000.0, dueDate.Add(commons.DifferentTime).Format("2006-01-02 15:04:05"), 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Reschedule(context.Background(), data)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `pay_loan` SET `amount`=?,`created_at`=? WHERE `id` = ?")).
+			WithArgs(1000.0, "2024-08-24 10:00:00", 1).
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		err := repo.Reschedule(context.Background(), data)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPayLoanRepository_SumTax(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT loan.currency AS currency, COALESCE(SUM(pay_loan.tax_amount), 0) AS tax_amount, COALESCE(SUM(pay_loan.withholding_tax_amount), 0) AS withholding_tax_amount FROM `pay_loan` JOIN loan ON loan.id = pay_loan.loan_id WHERE pay_loan.created_at >= ? AND pay_loan.created_at < ? AND pay_loan.status = ? GROUP BY `loan`.`currency`")).
+			WithArgs("2024-01-01 00:00:00", "2024-02-01 00:00:00", commons.StatusPayLoanPayed).
+			WillReturnRows(sqlmock.NewRows([]string{"currency", "tax_amount", "withholding_tax_amount"}).AddRow("IDR", 110, 75))
+
+		results, err := repo.SumTax(context.Background(), from, to, commons.StatusPayLoanPayed)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TaxSummaryEntity{{Currency: "IDR", TaxAmount: 110, WithholdingTaxAmount: 75}}, results)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT loan.currency AS currency, COALESCE(SUM(pay_loan.tax_amount), 0) AS tax_amount, COALESCE(SUM(pay_loan.withholding_tax_amount), 0) AS withholding_tax_amount FROM `pay_loan` JOIN loan ON loan.id = pay_loan.loan_id WHERE pay_loan.created_at >= ? AND pay_loan.created_at < ? AND pay_loan.status = ? GROUP BY `loan`.`currency`")).
+			WithArgs("2024-01-01 00:00:00", "2024-02-01 00:00:00", commons.StatusPayLoanPayed).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.SumTax(context.Background(), from, to, commons.StatusPayLoanPayed)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_ListByUsername(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "amount", "status", "created_at", "paid_at", "paid_amount"}).
+			AddRow(1, 123, 110.0, commons.StatusPayLoanPayed, "2024-08-05 00:00:00", "2024-08-04 10:00:00", 110.0)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT pay_loan.* FROM `pay_loan` JOIN loan ON loan.id = pay_loan.loan_id WHERE loan.username = ? AND pay_loan.status = ? AND pay_loan.paid_at < ? ORDER BY pay_loan.paid_at ASC, pay_loan.id ASC LIMIT ?")).
+			WithArgs("user123", commons.StatusPayLoanPayed, "2024-09-01 00:00:00", 21).
+			WillReturnRows(rows)
+
+		results, err := repo.ListByUsername(context.Background(), entity.HistoryFilter{
+			Username: "user123",
+			To:       time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+			Limit:    21,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, 123, results[0].LoanId)
+		assert.Equal(t, float64(110), results[0].PaidAmount)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT pay_loan.* FROM `pay_loan` JOIN loan")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.ListByUsername(context.Background(), entity.HistoryFilter{Username: "user123", Limit: 21})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_GetExposures(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	asOf := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"loan_id", "username", "currency", "product_code", "outstanding", "overdue_count", "oldest_due_date"}).
+			AddRow(1, "user123", "IDR", "WEEKLY_50", 5000000, 2, "2024-01-08 00:00:00")
+
+		mock.ExpectQuery("SELECT loan.id AS loan_id, loan.username AS username, loan.currency AS currency").
+			WithArgs("2024-03-01 00:00:00", commons.DefaultProductCode, "2024-03-01 00:00:00", commons.StatusPayLoanUnpayed, commons.StatusPayLoanPayed, "2024-03-01 00:00:00").
+			WillReturnRows(rows)
+
+		results, err := repo.GetExposures(context.Background(), asOf)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "user123", results[0].Username)
+		assert.Equal(t, "WEEKLY_50", results[0].ProductCode)
+		assert.Equal(t, float64(5000000), results[0].Outstanding)
+		assert.Equal(t, 2, results[0].OverdueCount)
+		assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), results[0].OldestDueDate)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT loan.id AS loan_id, loan.username AS username, loan.currency AS currency").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetExposures(context.Background(), asOf)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_SumCollections(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"currency", "count", "amount"}).
+			AddRow("IDR", 3, 16500000).
+			AddRow("USD", 1, 110.5)
+
+		mock.ExpectQuery("SELECT loan.currency AS currency, COUNT\\(\\*\\) AS count").
+			WithArgs(commons.StatusPayLoanPayed, "2024-03-01 00:00:00", "2024-03-02 00:00:00").
+			WillReturnRows(rows)
+
+		results, err := repo.SumCollections(context.Background(), from, to)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, 3, results[0].Count)
+		assert.Equal(t, 110.5, results[1].Amount)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT loan.currency AS currency, COUNT\\(\\*\\) AS count").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.SumCollections(context.Background(), from, to)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_StreamSchedules(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	filter := entity.ExportFilter{To: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "amount", "created_at", "status", "paid_at", "username", "currency"}).
+			AddRow(1, 10, 1100.0, "2024-01-08 00:00:00", commons.StatusPayLoanPayed, "2024-01-07 09:00:00", "user123", "IDR").
+			AddRow(2, 10, 1100.0, "2024-01-15 00:00:00", commons.StatusPayLoanUnpayed, nil, "user123", "IDR")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT pay_loan.*, loan.username, loan.currency FROM `pay_loan` JOIN loan ON loan.id = pay_loan.loan_id WHERE pay_loan.created_at < ? ORDER BY pay_loan.loan_id, pay_loan.created_at, pay_loan.id")).
+			WithArgs("2024-02-01 00:00:00").
+			WillReturnRows(rows)
+
+		results := []entity.InstallmentRowEntity{}
+		err := repo.StreamSchedules(context.Background(), filter, func(data entity.InstallmentRowEntity) error {
+			results = append(results, data)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "user123", results[0].Username)
+		assert.Equal(t, "IDR", results[0].Currency)
+		assert.Equal(t, 10, results[0].PayLoan.LoanId)
+		assert.Equal(t, time.Date(2024, 1, 7, 9, 0, 0, 0, time.UTC), results[0].PayLoan.PaidAt)
+		assert.True(t, results[1].PayLoan.PaidAt.IsZero())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT pay_loan.*, loan.username, loan.currency FROM `pay_loan`")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		err := repo.StreamSchedules(context.Background(), filter, func(data entity.InstallmentRowEntity) error {
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPayLoanRepository_StreamPayments(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPayLoanRepository(db)
+	filter := entity.ExportFilter{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "status", "paid_at", "paid_amount", "username", "currency"}).
+			AddRow(1, 10, commons.StatusPayLoanPayed, "2024-01-07 09:00:00", 1100.0, "user123", "IDR")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT pay_loan.*, loan.username, loan.currency FROM `pay_loan` JOIN loan ON loan.id = pay_loan.loan_id WHERE pay_loan.status = ? AND pay_loan.paid_at >= ? AND pay_loan.paid_at < ? ORDER BY pay_loan.paid_at, pay_loan.id")).
+			WithArgs(commons.StatusPayLoanPayed, "2024-01-01 00:00:00", "2024-02-01 00:00:00").
+			WillReturnRows(rows)
+
+		results := []entity.InstallmentRowEntity{}
+		err := repo.StreamPayments(context.Background(), filter, func(data entity.InstallmentRowEntity) error {
+			results = append(results, data)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, float64(1100), results[0].PayLoan.PaidAmount)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT pay_loan.*, loan.username, loan.currency FROM `pay_loan`")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		err := repo.StreamPayments(context.Background(), filter, func(data entity.InstallmentRowEntity) error {
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+}
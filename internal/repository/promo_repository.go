@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type IPromoRepository interface {
+	Get(ctx context.Context, code string) (entity.PromoEntity, error)
+	Use(ctx context.Context, code string) (bool, error)
+	Release(ctx context.Context, code string) error
+}
+
+type PromoRepository struct {
+	DB *gorm.DB
+}
+
+func NewPromoRepository(DB *gorm.DB) IPromoRepository {
+	return &PromoRepository{
+		DB: DB,
+	}
+}
+
+func (pr *PromoRepository) Get(ctx context.Context, code string) (entity.PromoEntity, error) {
+	model := models.PromoModel{}
+
+	if response := pr.DB.Table("promo").Where("code = ?", code).Find(&model); response.Error != nil {
+		return entity.PromoEntity{}, response.Error
+	}
+
+	return convertModelToEntityPromo(model), nil
+}
+
+// Use count one usage of promo, it return false when usage limit already reached
+func (pr *PromoRepository) Use(ctx context.Context, code string) (bool, error) {
+	response := pr.DB.Table("promo").
+		Where("code = ?", code).
+		Where("usage_limit = 0 OR usage_count < usage_limit").
+		Update("usage_count", gorm.Expr("usage_count + 1"))
+	if response.Error != nil {
+		return false, response.Error
+	}
+
+	return response.RowsAffected > 0, nil
+}
+
+// Release give back one usage of promo, used when loan that took the promo is cancelled
+func (pr *PromoRepository) Release(ctx context.Context, code string) error {
+	response := pr.DB.Table("promo").
+		Where("code = ?", code).
+		Where("usage_count > 0").
+		Update("usage_count", gorm.Expr("usage_count - 1"))
+	if response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+func convertModelToEntityPromo(model models.PromoModel) entity.PromoEntity {
+	return entity.PromoEntity{
+		Code:            model.Code,
+		Name:            model.Name,
+		DiscountType:    model.DiscountType,
+		DiscountValue:   model.DiscountValue,
+		ValidFrom:       parseNullableTime("2006-01-02 15:04:05", model.ValidFrom),
+		ValidTo:         parseNullableTime("2006-01-02 15:04:05", model.ValidTo),
+		UsageLimit:      model.UsageLimit,
+		UsageCount:      model.UsageCount,
+		ProductCode:     model.ProductCode,
+		MinAmount:       model.MinAmount,
+		MaxAmount:       model.MaxAmount,
+		NewBorrowerOnly: model.NewBorrowerOnly,
+	}
+}
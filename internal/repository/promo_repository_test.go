@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPromoRepository_Get(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPromoRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"code", "name", "discount_type", "discount_value", "valid_from", "valid_to", "usage_limit", "usage_count", "new_borrower_only"}).
+			AddRow("FREE4", "Free first month", commons.PromoDiscountInterestFree, 4, "2024-01-01 00:00:00", nil, 100, 10, true)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `promo` WHERE code = ?")).
+			WithArgs("FREE4").
+			WillReturnRows(rows)
+
+		result, err := repo.Get(context.Background(), "FREE4")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "FREE4", result.Code)
+		assert.Equal(t, commons.PromoDiscountInterestFree, result.DiscountType)
+		assert.Equal(t, float64(4), result.DiscountValue)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), result.ValidFrom)
+		assert.True(t, result.ValidTo.IsZero())
+		assert.True(t, result.NewBorrowerOnly)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `promo` WHERE code = ?")).
+			WithArgs("FREE4").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.Get(context.Background(), "FREE4")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPromoRepository_Use(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPromoRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `promo` SET `usage_count`=usage_count + 1 WHERE code = ? AND (usage_limit = 0 OR usage_count < usage_limit)")).
+			WithArgs("FREE4").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		used, err := repo.Use(context.Background(), "FREE4")
+
+		assert.NoError(t, err)
+		assert.True(t, used)
+	})
+
+	t.Run("usage limit reached", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `promo` SET `usage_count`=usage_count + 1 WHERE code = ? AND (usage_limit = 0 OR usage_count < usage_limit)")).
+			WithArgs("FREE4").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		used, err := repo.Use(context.Background(), "FREE4")
+
+		assert.NoError(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `promo` SET `usage_count`=usage_count + 1 WHERE code = ? AND (usage_limit = 0 OR usage_count < usage_limit)")).
+			WithArgs("FREE4").
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		_, err := repo.Use(context.Background(), "FREE4")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPromoRepository_Release(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewPromoRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `promo` SET `usage_count`=usage_count - 1 WHERE code = ? AND usage_count > 0")).
+			WithArgs("FREE4").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Release(context.Background(), "FREE4")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `promo` SET `usage_count`=usage_count - 1 WHERE code = ? AND usage_count > 0")).
+			WithArgs("FREE4").
+			WillReturnError(gorm.ErrInvalidData)
+		mock.ExpectRollback()
+
+		err := repo.Release(context.Background(), "FREE4")
+
+		assert.Error(t, err)
+	})
+}
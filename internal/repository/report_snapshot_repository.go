@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReportSnapshotRepository interface {
+	Save(ctx context.Context, datas []entity.ReportSnapshotEntity) error
+	GetByDate(ctx context.Context, reportDate time.Time) ([]entity.ReportSnapshotEntity, error)
+}
+
+type ReportSnapshotRepository struct {
+	DB *gorm.DB
+}
+
+func NewReportSnapshotRepository(DB *gorm.DB) IReportSnapshotRepository {
+	return &ReportSnapshotRepository{
+		DB: DB,
+	}
+}
+
+// Save insert snapshot, snapshot of same date and currency is replaced
+func (rsr *ReportSnapshotRepository) Save(ctx context.Context, datas []entity.ReportSnapshotEntity) error {
+	if len(datas) == 0 {
+		return nil
+	}
+
+	models := []models.ReportSnapshotModel{}
+	for _, data := range datas {
+		models = append(models, convertEntityToModelReportSnapshot(data))
+	}
+
+	if response := rsr.DB.Table("report_snapshot").Clauses(clause.OnConflict{UpdateAll: true}).Create(&models); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (rsr *ReportSnapshotRepository) GetByDate(ctx context.Context, reportDate time.Time) ([]entity.ReportSnapshotEntity, error) {
+	models := []models.ReportSnapshotModel{}
+
+	if response := rsr.DB.Table("report_snapshot").Where("report_date = ?", reportDate.Format("2006-01-02")).Order("currency").Find(&models); response.Error != nil {
+		return []entity.ReportSnapshotEntity{}, response.Error
+	}
+
+	result := []entity.ReportSnapshotEntity{}
+	for _, model := range models {
+		result = append(result, convertModelToEntityReportSnapshot(model))
+	}
+
+	return result, nil
+}
+
+func convertEntityToModelReportSnapshot(data entity.ReportSnapshotEntity) models.ReportSnapshotModel {
+	return models.ReportSnapshotModel{
+		ReportDate:          data.ReportDate.Format("2006-01-02"),
+		Currency:            data.Currency,
+		LoanCount:           data.LoanCount,
+		Outstanding:         data.Outstanding,
+		Par30Amount:         data.Par30Amount,
+		Par90Amount:         data.Par90Amount,
+		Par30:               data.Par30,
+		Par90:               data.Par90,
+		DelinquentBorrowers: data.DelinquentBorrowers,
+		CollectionCount:     data.CollectionCount,
+		Collections:         data.Collections,
+		CreatedAt:           data.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func convertModelToEntityReportSnapshot(model models.ReportSnapshotModel) entity.ReportSnapshotEntity {
+	reportDate, _ := time.Parse("2006-01-02", model.ReportDate)
+	createdAt, _ := time.Parse("2006-01-02 15:04:05", model.CreatedAt)
+
+	return entity.ReportSnapshotEntity{
+		ReportDate:          reportDate,
+		Currency:            model.Currency,
+		LoanCount:           model.LoanCount,
+		Outstanding:         model.Outstanding,
+		Par30Amount:         model.Par30Amount,
+		Par90Amount:         model.Par90Amount,
+		Par30:               model.Par30,
+		Par90:               model.Par90,
+		DelinquentBorrowers: model.DelinquentBorrowers,
+		CollectionCount:     model.CollectionCount,
+		Collections:         model.Collections,
+		CreatedAt:           createdAt,
+	}
+}
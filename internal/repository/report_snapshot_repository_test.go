@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestReportSnapshotRepository_Save(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewReportSnapshotRepository(db)
+	data := entity.ReportSnapshotEntity{
+		ReportDate:          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Currency:            "IDR",
+		LoanCount:           2,
+		Outstanding:         11000000,
+		Par30Amount:         5500000,
+		Par30:               0.5,
+		DelinquentBorrowers: 1,
+		CollectionCount:     1,
+		Collections:         550000,
+		CreatedAt:           time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `report_snapshot`")).
+			WithArgs("2024-03-01", "IDR", 2, float64(11000000), float64(5500000), float64(0), 0.5, float64(0), 1, 1, float64(550000), "2024-03-02 00:00:00").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(context.Background(), []entity.ReportSnapshotEntity{data})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		err := repo.Save(context.Background(), []entity.ReportSnapshotEntity{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `report_snapshot`")).
+			WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		err := repo.Save(context.Background(), []entity.ReportSnapshotEntity{data})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReportSnapshotRepository_GetByDate(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewReportSnapshotRepository(db)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"report_date", "currency", "loan_count", "outstanding", "par30", "created_at"}).
+			AddRow("2024-03-01", "IDR", 2, 11000000, 0.5, "2024-03-02 00:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `report_snapshot` WHERE report_date = ? ORDER BY currency")).
+			WithArgs("2024-03-01").
+			WillReturnRows(rows)
+
+		results, err := repo.GetByDate(context.Background(), date)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, date, results[0].ReportDate)
+		assert.Equal(t, 0.5, results[0].Par30)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `report_snapshot` WHERE report_date = ? ORDER BY currency")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetByDate(context.Background(), date)
+
+		assert.Error(t, err)
+	})
+}
@@ -1,7 +1,73 @@
 package repository
 
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
 type Repository struct {
-	Loan    ILoanRepository
-	User    IUserRepository
-	PayLoan IPayLoanRepository
+	Loan            ILoanRepository
+	User            IUserRepository
+	PayLoan         IPayLoanRepository
+	LoanRestructure ILoanRestructureRepository
+	LoanProduct     ILoanProductRepository
+	Holiday         IHolidayRepository
+	Transaction     ITransactionRepository
+	TaxRule         ITaxRuleRepository
+	Promo           IPromoRepository
+	ReportSnapshot  IReportSnapshotRepository
+	Statement       IStatementRepository
+	JobRun          IJobRunRepository
+	Lock            ILockRepository
+}
+
+func NewRepository(DB *gorm.DB) *Repository {
+	return &Repository{
+		Loan:            NewLoanRepository(DB),
+		User:            NewUserRepository(DB),
+		PayLoan:         NewPayLoanRepository(DB),
+		LoanRestructure: NewLoanRestructureRepository(DB),
+		LoanProduct:     NewLoanProductRepository(DB),
+		Holiday:         NewHolidayRepository(DB),
+		Transaction:     NewTransactionRepository(DB),
+		TaxRule:         NewTaxRuleRepository(DB),
+		Promo:           NewPromoRepository(DB),
+		ReportSnapshot:  NewReportSnapshotRepository(DB),
+		Statement:       NewStatementRepository(DB),
+		JobRun:          NewJobRunRepository(DB),
+		Lock:            NewLockRepository(DB),
+	}
+}
+
+// translateError replace gorm not found error with ErrNotFound so caller does not depend on gorm
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// formatNullableTime returns nil for zero time so the column is stored as NULL
+func formatNullableTime(layout string, t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	formatted := t.Format(layout)
+	return &formatted
+}
+
+func parseNullableTime(layout string, value *string) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+
+	parsed, _ := time.Parse(layout, *value)
+	return parsed
 }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IStatementRepository interface {
+	Save(ctx context.Context, data entity.StatementEntity) error
+	Get(ctx context.Context, loanId int, periodStart time.Time, periodEnd time.Time, format string) (entity.StatementEntity, error)
+	Exists(ctx context.Context, loanId int, periodStart time.Time, periodEnd time.Time, format string) (bool, error)
+}
+
+type StatementRepository struct {
+	DB *gorm.DB
+}
+
+func NewStatementRepository(DB *gorm.DB) IStatementRepository {
+	return &StatementRepository{
+		DB: DB,
+	}
+}
+
+// Save insert statement, statement of same loan, period and format is replaced
+func (sr *StatementRepository) Save(ctx context.Context, data entity.StatementEntity) error {
+	model := convertEntityToModelStatement(data)
+
+	if response := sr.DB.Table("statement").Omit("id").Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"content", "created_at"}),
+	}).Create(&model); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+func (sr *StatementRepository) Get(ctx context.Context, loanId int, periodStart time.Time, periodEnd time.Time, format string) (entity.StatementEntity, error) {
+	model := models.StatementModel{}
+
+	if response := sr.DB.Table("statement").
+		Where("loan_id = ? AND period_start = ? AND period_end = ? AND format = ?", loanId, periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02"), format).
+		First(&model); response.Error != nil {
+		return entity.StatementEntity{}, translateError(response.Error)
+	}
+
+	return convertModelToEntityStatement(model), nil
+}
+
+func (sr *StatementRepository) Exists(ctx context.Context, loanId int, periodStart time.Time, periodEnd time.Time, format string) (bool, error) {
+	var count int64
+
+	if response := sr.DB.Table("statement").
+		Where("loan_id = ? AND period_start = ? AND period_end = ? AND format = ?", loanId, periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02"), format).
+		Count(&count); response.Error != nil {
+		return false, response.Error
+	}
+
+	return count > 0, nil
+}
+
+func convertEntityToModelStatement(data entity.StatementEntity) models.StatementModel {
+	return models.StatementModel{
+		Id:          data.Id,
+		LoanId:      data.LoanId,
+		PeriodStart: data.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:   data.PeriodEnd.Format("2006-01-02"),
+		Format:      data.Format,
+		Content:     data.Content,
+		CreatedAt:   data.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func convertModelToEntityStatement(model models.StatementModel) entity.StatementEntity {
+	periodStart, _ := time.Parse("2006-01-02", model.PeriodStart)
+	periodEnd, _ := time.Parse("2006-01-02", model.PeriodEnd)
+	createdAt, _ := time.Parse("2006-01-02 15:04:05", model.CreatedAt)
+
+	return entity.StatementEntity{
+		Id:          model.Id,
+		LoanId:      model.LoanId,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Format:      model.Format,
+		Content:     model.Content,
+		CreatedAt:   createdAt,
+	}
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestStatementRepository_Save(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewStatementRepository(db)
+	data := entity.StatementEntity{
+		LoanId:      10,
+		PeriodStart: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		Format:      "pdf",
+		Content:     []byte("%PDF-1.4"),
+		CreatedAt:   time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `statement` (`loan_id`,`period_start`,`period_end`,`format`,`content`,`created_at`) VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `content`=VALUES(`content`),`created_at`=VALUES(`created_at`)")).
+			WithArgs(10, "2024-02-01", "2024-02-29", "pdf", []byte("%PDF-1.4"), "2024-03-01 01:00:00").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(context.Background(), data)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `statement`")).
+			WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		err := repo.Save(context.Background(), data)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestStatementRepository_Get(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewStatementRepository(db)
+	periodStart := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "loan_id", "period_start", "period_end", "format", "content", "created_at"}).
+			AddRow(1, 10, "2024-02-01", "2024-02-29", "html", []byte("<html>"), "2024-03-01 01:00:00")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `statement` WHERE loan_id = ? AND period_start = ? AND period_end = ? AND format = ? ORDER BY `statement`.`id` LIMIT ?")).
+			WithArgs(10, "2024-02-01", "2024-02-29", "html", 1).
+			WillReturnRows(rows)
+
+		result, err := repo.Get(context.Background(), 10, periodStart, periodEnd, "html")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("<html>"), result.Content)
+		assert.Equal(t, periodEnd, result.PeriodEnd)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `statement`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.Get(context.Background(), 10, periodStart, periodEnd, "html")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestStatementRepository_Exists(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewStatementRepository(db)
+	periodStart := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `statement` WHERE loan_id = ? AND period_start = ? AND period_end = ? AND format = ?")).
+			WithArgs(10, "2024-02-01", "2024-02-29", "pdf").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		exists, err := repo.Exists(context.Background(), 10, periodStart, periodEnd, "pdf")
+
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `statement`")).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.Exists(context.Background(), 10, periodStart, periodEnd, "pdf")
+
+		assert.Error(t, err)
+	})
+}
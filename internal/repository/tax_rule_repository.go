@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type ITaxRuleRepository interface {
+	GetByCountry(ctx context.Context, country string) ([]entity.TaxRuleEntity, error)
+}
+
+type TaxRuleRepository struct {
+	DB *gorm.DB
+}
+
+func NewTaxRuleRepository(DB *gorm.DB) ITaxRuleRepository {
+	return &TaxRuleRepository{
+		DB: DB,
+	}
+}
+
+func (trr *TaxRuleRepository) GetByCountry(ctx context.Context, country string) ([]entity.TaxRuleEntity, error) {
+	models := []models.TaxRuleModel{}
+
+	if response := trr.DB.Table("tax_rule").Where("country = ?", country).Find(&models); response.Error != nil {
+		return []entity.TaxRuleEntity{}, response.Error
+	}
+
+	result := []entity.TaxRuleEntity{}
+	for _, model := range models {
+		result = append(result, convertModelToEntityTaxRule(model))
+	}
+
+	return result, nil
+}
+
+func convertModelToEntityTaxRule(model models.TaxRuleModel) entity.TaxRuleEntity {
+	return entity.TaxRuleEntity{
+		Id:      model.Id,
+		Country: model.Country,
+		Type:    model.Type,
+		Rate:    model.Rate,
+		Name:    model.Name,
+	}
+}
+
+func convertModelsToEntitiesTaxSummary(models []models.TaxSummaryModel) []entity.TaxSummaryEntity {
+	result := []entity.TaxSummaryEntity{}
+	for _, model := range models {
+		result = append(result, entity.TaxSummaryEntity{
+			Currency:             model.Currency,
+			TaxAmount:            model.TaxAmount,
+			WithholdingTaxAmount: model.WithholdingTaxAmount,
+		})
+	}
+
+	return result
+}
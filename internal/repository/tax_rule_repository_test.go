@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTaxRuleRepository_GetByCountry(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewTaxRuleRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "country", "type", "rate", "name"}).
+			AddRow(1, "ID", commons.TaxTypeVat, 11, "PPN").
+			AddRow(2, "ID", commons.TaxTypeWithholding, 15, "PPh 23")
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tax_rule` WHERE country = ?")).
+			WithArgs("ID").
+			WillReturnRows(rows)
+
+		results, err := repo.GetByCountry(context.Background(), "ID")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, commons.TaxTypeVat, results[0].Type)
+		assert.Equal(t, float64(11), results[0].Rate)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tax_rule` WHERE country = ?")).
+			WithArgs("ID").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		_, err := repo.GetByCountry(context.Background(), "ID")
+
+		assert.Error(t, err)
+	})
+}
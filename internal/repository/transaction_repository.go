@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ITransactionRepository interface {
+	WithTransaction(ctx context.Context, fn func(repo *Repository) error) error
+}
+
+type TransactionRepository struct {
+	DB *gorm.DB
+}
+
+func NewTransactionRepository(DB *gorm.DB) ITransactionRepository {
+	return &TransactionRepository{
+		DB: DB,
+	}
+}
+
+// WithTransaction run fn with repositories bound to one database transaction,
+// commit when fn return nil and rollback otherwise
+func (tr *TransactionRepository) WithTransaction(ctx context.Context, fn func(repo *Repository) error) error {
+	return tr.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepository(tx))
+	})
+}
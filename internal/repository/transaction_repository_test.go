@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRepository_WithTransaction(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	repo := NewTransactionRepository(db)
+
+	t.Run("commit", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `status`=? WHERE `id` = ?")).
+			WithArgs(commons.StatusLoanClosed, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.WithTransaction(context.Background(), func(tx *Repository) error {
+			return tx.Loan.UpdateStatus(context.Background(), 1, commons.StatusLoanClosed)
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `loan` SET `status`=? WHERE `id` = ?")).
+			WithArgs(commons.StatusLoanClosed, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		err := repo.WithTransaction(context.Background(), func(tx *Repository) error {
+			err := tx.Loan.UpdateStatus(context.Background(), 1, commons.StatusLoanClosed)
+			if err != nil {
+				return err
+			}
+
+			return errors.New("any error after update")
+		})
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
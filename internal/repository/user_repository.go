@@ -12,8 +12,9 @@ import (
 
 type IUserRepository interface {
 	GetUser(ctx context.Context, username string) (entity.UserEntity, error)
-	Create(ctx context.Context, username string) (entity.UserEntity, error)
+	Create(ctx context.Context, data entity.UserEntity) (entity.UserEntity, error)
 	UpdateUser(ctx context.Context, username string, status int) error
+	UpdateProfile(ctx context.Context, username string, data entity.UserEntity) error
 }
 
 type UserRepository struct {
@@ -32,20 +33,15 @@ func NewUserRepository(DB *gorm.DB) IUserRepository {
 	}
 }
 
-func (ur *UserRepository) Create(ctx context.Context, username string) (entity.UserEntity, error) {
-	model := models.UserModel{
-		Username: username,
-		Status:   commons.StatusUserNew,
-	}
+func (ur *UserRepository) Create(ctx context.Context, data entity.UserEntity) (entity.UserEntity, error) {
+	model := convertEntityToModelUser(data)
+	model.Status = commons.StatusUserNew
 
 	if response := ur.DB.Table("users").Create(&model); response.Error != nil {
 		return entity.UserEntity{}, response.Error
 	}
 
-	return entity.UserEntity{
-		Username: model.Username,
-		Status:   model.Status,
-	}, nil
+	return convertModelToEntityUser(model), nil
 }
 
 func (ur *UserRepository) GetUser(ctx context.Context, username string) (entity.UserEntity, error) {
@@ -55,10 +51,7 @@ func (ur *UserRepository) GetUser(ctx context.Context, username string) (entity.
 		return entity.UserEntity{}, response.Error
 	}
 
-	return entity.UserEntity{
-		Username: model.Username,
-		Status:   model.Status,
-	}, nil
+	return convertModelToEntityUser(model), nil
 }
 
 func (ur *UserRepository) UpdateUser(ctx context.Context, username string, status int) error {
@@ -68,3 +61,55 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, username string, statu
 
 	return nil
 }
+
+func (ur *UserRepository) UpdateProfile(ctx context.Context, username string, data entity.UserEntity) error {
+	model := convertEntityToModelUser(data)
+
+	if response := ur.DB.Table("users").Where("username = ?", username).Updates(map[string]interface{}{
+		"full_name":        model.FullName,
+		"national_id":      model.NationalId,
+		"phone":            model.Phone,
+		"email":            model.Email,
+		"address":          model.Address,
+		"date_of_birth":    model.DateOfBirth,
+		"kyc_status":       model.KycStatus,
+		"kyc_submitted_at": model.KycSubmittedAt,
+		"kyc_verified_at":  model.KycVerifiedAt,
+	}); response.Error != nil {
+		return response.Error
+	}
+
+	return nil
+}
+
+func convertModelToEntityUser(model models.UserModel) entity.UserEntity {
+	return entity.UserEntity{
+		Username:       model.Username,
+		FullName:       model.FullName,
+		NationalId:     model.NationalId,
+		Phone:          model.Phone,
+		Email:          model.Email,
+		Address:        model.Address,
+		DateOfBirth:    parseNullableTime("2006-01-02", model.DateOfBirth),
+		KycStatus:      model.KycStatus,
+		KycSubmittedAt: parseNullableTime("2006-01-02 15:04:05", model.KycSubmittedAt),
+		KycVerifiedAt:  parseNullableTime("2006-01-02 15:04:05", model.KycVerifiedAt),
+		Status:         model.Status,
+	}
+}
+
+func convertEntityToModelUser(entity entity.UserEntity) models.UserModel {
+	return models.UserModel{
+		Username:       entity.Username,
+		FullName:       entity.FullName,
+		NationalId:     entity.NationalId,
+		Phone:          entity.Phone,
+		Email:          entity.Email,
+		Address:        entity.Address,
+		DateOfBirth:    formatNullableTime("2006-01-02", entity.DateOfBirth),
+		KycStatus:      entity.KycStatus,
+		KycSubmittedAt: formatNullableTime("2006-01-02 15:04:05", entity.KycSubmittedAt),
+		KycVerifiedAt:  formatNullableTime("2006-01-02 15:04:05", entity.KycVerifiedAt),
+		Status:         entity.Status,
+	}
+}
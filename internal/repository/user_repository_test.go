@@ -4,9 +4,11 @@ import (
 	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
@@ -17,30 +19,43 @@ func TestUserRepository_Create(t *testing.T) {
 	repo := NewUserRepository(db)
 
 	t.Run("success", func(t *testing.T) {
-		username := "user123"
+		data := entity.UserEntity{
+			Username:       "user123",
+			FullName:       "User Satu",
+			NationalId:     "3171000000000001",
+			Phone:          "08123456789",
+			Email:          "user123@mail.com",
+			Address:        "Jakarta",
+			DateOfBirth:    time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+			KycStatus:      commons.StatusKycPending,
+			KycSubmittedAt: time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC),
+		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`status`) VALUES (?,?)")).
-			WithArgs(username, commons.StatusUserNew).
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`full_name`,`national_id`,`phone`,`email`,`address`,`date_of_birth`,`kyc_status`,`kyc_submitted_at`,`kyc_verified_at`,`status`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
+			WithArgs(data.Username, data.FullName, data.NationalId, data.Phone, data.Email, data.Address, "1990-01-02", commons.StatusKycPending, "2024-08-24 10:00:00", nil, commons.StatusUserNew).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
-		user, err := repo.Create(context.Background(), username)
+		user, err := repo.Create(context.Background(), data)
 		require.NoError(t, err)
-		assert.Equal(t, username, user.Username)
+		assert.Equal(t, data.Username, user.Username)
+		assert.Equal(t, data.DateOfBirth, user.DateOfBirth)
 		assert.Equal(t, commons.StatusUserNew, user.Status)
+		assert.True(t, user.KycVerifiedAt.IsZero())
 	})
 
 	t.Run("error", func(t *testing.T) {
-		username := "user123"
+		data := entity.UserEntity{
+			Username: "user123",
+		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`status`) VALUES (?, ?)")).
-			WithArgs(username, commons.StatusUserNew).
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users`")).
 			WillReturnError(gorm.ErrInvalidDB)
 		mock.ExpectRollback()
 
-		_, err := repo.Create(context.Background(), username)
+		_, err := repo.Create(context.Background(), data)
 		assert.Error(t, err)
 	})
 }
@@ -51,8 +66,8 @@ func TestUserRepository_GetUser(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		username := "user123"
-		row := sqlmock.NewRows([]string{"username", "status"}).
-			AddRow(username, commons.StatusUserNew)
+		row := sqlmock.NewRows([]string{"username", "status", "kyc_status", "date_of_birth", "kyc_verified_at"}).
+			AddRow(username, commons.StatusUserNew, commons.StatusKycVerified, "1990-01-02", "2024-08-24 10:00:00")
 
 		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE username = ?")).
 			WithArgs(username).
@@ -62,6 +77,9 @@ func TestUserRepository_GetUser(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, username, user.Username)
 		assert.Equal(t, commons.StatusUserNew, user.Status)
+		assert.Equal(t, commons.StatusKycVerified, user.KycStatus)
+		assert.Equal(t, time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC), user.DateOfBirth)
+		assert.Equal(t, time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC), user.KycVerifiedAt)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -108,3 +126,44 @@ func TestUserRepository_UpdateUser(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestUserRepository_UpdateProfile(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewUserRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		username := "user123"
+		data := entity.UserEntity{
+			FullName:      "User Satu",
+			NationalId:    "3171000000000001",
+			Phone:         "08123456789",
+			Email:         "user123@mail.com",
+			Address:       "Jakarta",
+			DateOfBirth:   time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+			KycStatus:     commons.StatusKycVerified,
+			KycVerifiedAt: time.Date(2024, 8, 24, 10, 0, 0, 0, time.UTC),
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `address`=?,`date_of_birth`=?,`email`=?,`full_name`=?,`kyc_status`=?,`kyc_submitted_at`=?,`kyc_verified_at`=?,`national_id`=?,`phone`=? WHERE username = ?")).
+			WithArgs(data.Address, "1990-01-02", data.Email, data.FullName, commons.StatusKycVerified, nil, "2024-08-24 10:00:00", data.NationalId, data.Phone, username).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateProfile(context.Background(), username, data)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		username := "user123"
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET")).
+			WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		err := repo.UpdateProfile(context.Background(), username, entity.UserEntity{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		err  string
+	}{
+		{name: "five fields", spec: "5 0 * * *"},
+		{name: "six fields", spec: "*/30 * * * * *"},
+		{name: "descriptor", spec: "@daily"},
+		{name: "list range step", spec: "0 8-18/2 1,15 * 1-5"},
+		{name: "too few fields", spec: "* * *", err: `cron expression "* * *" must have 5 or 6 fields`},
+		{name: "out of range", spec: "60 * * * *", err: `cron expression "60 * * * *": value "60" out of range 0-59`},
+		{name: "invalid step", spec: "*/0 * * * *", err: `cron expression "*/0 * * * *": invalid step "*/0"`},
+		{name: "invalid range", spec: "0 5-1 * * *", err: `cron expression "0 5-1 * * *": invalid range "5-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02 15:04:05", value)
+		return parsed
+	}
+
+	tests := []struct {
+		spec string
+		from string
+		next string
+	}{
+		{spec: "*/30 * * * * *", from: "2024-01-01 10:00:10", next: "2024-01-01 10:00:30"},
+		{spec: "*/30 * * * * *", from: "2024-01-01 10:00:30", next: "2024-01-01 10:01:00"},
+		{spec: "5 0 * * *", from: "2024-01-01 00:05:00", next: "2024-01-02 00:05:00"},
+		{spec: "30 0 1 * *", from: "2024-01-15 00:00:00", next: "2024-02-01 00:30:00"},
+		{spec: "@monthly", from: "2024-12-31 23:59:59", next: "2025-01-01 00:00:00"},
+		{spec: "0 9 * * 1", from: "2024-01-01 09:00:00", next: "2024-01-08 09:00:00"},
+		{spec: "0 0 * * 7", from: "2024-01-01 00:00:00", next: "2024-01-07 00:00:00"},
+		// day of month or day of week when both restricted
+		{spec: "0 0 13 * 5", from: "2024-01-01 00:00:00", next: "2024-01-05 00:00:00"},
+		{spec: "0 0 29 2 *", from: "2024-03-01 00:00:00", next: "2028-02-29 00:00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+" from "+tt.from, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, at(tt.next), schedule.Next(at(tt.from)))
+		})
+	}
+
+	t.Run("never match", func(t *testing.T) {
+		schedule, err := Parse("0 0 31 2 *")
+		assert.NoError(t, err)
+		assert.True(t, schedule.Next(at("2024-01-01 00:00:00")).IsZero())
+	})
+}
@@ -0,0 +1,282 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// RunFunc run job for scheduled time, scheduled time is slot of the cron expression not the time job started
+type RunFunc func(ctx context.Context, scheduledAt time.Time) error
+
+// Job is registered job, CatchUp is max number of missed run executed after downtime, oldest missed run is dropped first
+type Job struct {
+	Name     string
+	Spec     string
+	CatchUp  int
+	Run      RunFunc
+	schedule *Schedule
+	running  atomic.Bool
+}
+
+// LockName is name of lease lock held by instance that run the jobs
+const LockName = "scheduler"
+
+// ErrNotLeader is returned by RunNow when lease is held by other instance
+var ErrNotLeader = errors.New("scheduler lease is held by other instance")
+
+// Scheduler run registered jobs on their cron schedule and record every run in job_runs.
+// Only instance holding the lease lock run jobs, lease is renewed every poll and taken over by
+// other instance when it is not renewed for lease ttl. One job never overlap with itself in the process,
+// and one scheduled time is run at most once because run is recorded before started
+type Scheduler struct {
+	repo         repository.IJobRunRepository
+	lock         repository.ILockRepository
+	owner        string
+	pollInterval time.Duration
+	leaseTTL     time.Duration
+	clock        clock.Clock
+
+	mu         sync.Mutex
+	jobs       []*Job
+	startedAt  time.Time
+	wg         sync.WaitGroup
+	leaderCtx  context.Context
+	leaderStop context.CancelFunc
+}
+
+// NewScheduler create scheduler that find due job by time of clk, lease ttl is three poll interval so one missed renewal not lose the lease
+func NewScheduler(repo repository.IJobRunRepository, lock repository.ILockRepository, clk clock.Clock, pollInterval time.Duration) *Scheduler {
+	hostname, _ := os.Hostname()
+
+	return &Scheduler{
+		repo:         repo,
+		lock:         lock,
+		owner:        fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano()),
+		pollInterval: pollInterval,
+		leaseTTL:     3 * pollInterval,
+		clock:        clk,
+	}
+}
+
+// Register add job with cron expression, catchUp less than one is treated as one
+func (s *Scheduler) Register(name string, spec string, catchUp int, run RunFunc) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+
+	if catchUp < 1 {
+		catchUp = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return fmt.Errorf("job %s already registered", name)
+		}
+	}
+
+	s.jobs = append(s.jobs, &Job{
+		Name:     name,
+		Spec:     spec,
+		CatchUp:  catchUp,
+		Run:      run,
+		schedule: schedule,
+	})
+
+	return nil
+}
+
+// Start check jobs every poll interval until ctx done, then wait running jobs to finish
+func (s *Scheduler) Start(ctx context.Context) {
+	s.startedAt = s.clock.Now()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.Tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			if err := s.lock.Release(context.Background(), LockName, s.owner); err != nil {
+				log.Println("scheduler release lock", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick renew lease and start every job that has due run and not running now, nothing run without the lease
+func (s *Scheduler) Tick(ctx context.Context) {
+	s.tick(ctx)
+}
+
+// RunNow tick right away and wait jobs started by it to finish, job already running is left to next poll.
+// Used by simulation after virtual clock moved, so run still go through the lease and no overlap guard
+func (s *Scheduler) RunNow(ctx context.Context) error {
+	started, ok := s.tick(ctx)
+	if !ok {
+		return ErrNotLeader
+	}
+
+	started.Wait()
+
+	return nil
+}
+
+// tick start due jobs and return wait group of jobs it started, false when instance not hold the lease
+func (s *Scheduler) tick(ctx context.Context) (*sync.WaitGroup, bool) {
+	leaderCtx, ok := s.lead(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	jobs := append([]*Job{}, s.jobs...)
+	s.mu.Unlock()
+
+	started := &sync.WaitGroup{}
+	now := s.clock.Now()
+	for _, job := range jobs {
+		if !job.running.CompareAndSwap(false, true) {
+			continue
+		}
+
+		s.wg.Add(1)
+		started.Add(1)
+		go func(job *Job) {
+			defer s.wg.Done()
+			defer started.Done()
+			defer job.running.Store(false)
+
+			if err := s.runDue(leaderCtx, job, now); err != nil {
+				log.Println("scheduler job", job.Name, err)
+			}
+		}(job)
+	}
+
+	return started, true
+}
+
+// lead acquire or renew lease, context of running jobs is cancelled when lease lost
+func (s *Scheduler) lead(ctx context.Context) (context.Context, bool) {
+	acquired, err := s.lock.Acquire(ctx, LockName, s.owner, s.leaseTTL)
+	if err != nil {
+		log.Println("scheduler acquire lock", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !acquired {
+		if s.leaderStop != nil {
+			log.Println("scheduler lost leadership", s.owner)
+			s.leaderStop()
+			s.leaderCtx, s.leaderStop = nil, nil
+		}
+		return nil, false
+	}
+
+	if s.leaderCtx == nil {
+		log.Println("scheduler become leader", s.owner)
+		s.leaderCtx, s.leaderStop = context.WithCancel(ctx)
+	}
+
+	return s.leaderCtx, true
+}
+
+// Runs return latest runs of job, newest first
+func (s *Scheduler) Runs(ctx context.Context, jobName string, limit int) ([]entity.JobRunEntity, error) {
+	return s.repo.ListByJob(ctx, jobName, limit)
+}
+
+// runDue run every missed scheduled time of job since its last recorded run up to now, oldest first
+func (s *Scheduler) runDue(ctx context.Context, job *Job, now time.Time) error {
+	slots, err := s.dueSlots(ctx, job, now)
+	if err != nil {
+		return err
+	}
+
+	for _, scheduledAt := range slots {
+		if err := s.runSlot(ctx, job, scheduledAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dueSlots return scheduled time after last run until now, keeping only latest CatchUp of them.
+// Job never run before start from time scheduler started, so history before the job exist is not run
+func (s *Scheduler) dueSlots(ctx context.Context, job *Job, now time.Time) ([]time.Time, error) {
+	last, err := s.repo.GetLast(ctx, job.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	from := s.startedAt
+	if !last.ScheduledAt.IsZero() {
+		from = last.ScheduledAt.In(now.Location())
+	}
+	if from.IsZero() {
+		from = now
+	}
+
+	slots := []time.Time{}
+	for next := job.schedule.Next(from); !next.IsZero() && !next.After(now); next = job.schedule.Next(next) {
+		slots = append(slots, next)
+		if len(slots) > job.CatchUp {
+			slots = slots[1:]
+		}
+	}
+
+	return slots, nil
+}
+
+// runSlot record run then execute job, run already recorded by other process is skipped.
+// Panic of job is recovered and recorded as failed run
+func (s *Scheduler) runSlot(ctx context.Context, job *Job, scheduledAt time.Time) (err error) {
+	run, started, err := s.repo.Start(ctx, entity.JobRunEntity{
+		JobName:     job.Name,
+		ScheduledAt: scheduledAt.UTC(),
+		StartedAt:   s.clock.Now().UTC(),
+		Status:      commons.StatusJobRunRunning,
+	})
+	if err != nil || !started {
+		return err
+	}
+
+	runErr := func() (runErr error) {
+		defer func() {
+			if r := recover(); r != nil {
+				runErr = fmt.Errorf("panic: %v", r)
+			}
+		}()
+
+		return job.Run(ctx, scheduledAt)
+	}()
+
+	status, message := commons.StatusJobRunSuccess, ""
+	if runErr != nil {
+		status, message = commons.StatusJobRunFailed, runErr.Error()
+		log.Println("scheduler job", job.Name, "failed for", scheduledAt.Format("2006-01-02 15:04:05"), runErr)
+	}
+
+	return s.repo.Finish(ctx, run.Id, status, message, s.clock.Now().UTC())
+}
@@ -0,0 +1,254 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScheduler(t *testing.T, now time.Time) (*Scheduler, *mock_repositories.MockIJobRunRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	jobRunRepoMock := mock_repositories.NewMockIJobRunRepository(ctrl)
+	lockRepoMock := mock_repositories.NewMockILockRepository(ctrl)
+	lockRepoMock.EXPECT().Acquire(gomock.Any(), LockName, gomock.Any(), 3*time.Second).Return(true, nil).AnyTimes()
+
+	s := NewScheduler(jobRunRepoMock, lockRepoMock, clock.NewSimulated(now), time.Second)
+
+	return s, jobRunRepoMock
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s, _ := newTestScheduler(t, time.Now())
+	run := func(ctx context.Context, scheduledAt time.Time) error { return nil }
+
+	assert.NoError(t, s.Register("daily", "@daily", 0, run))
+	assert.Equal(t, 1, s.jobs[0].CatchUp)
+	assert.EqualError(t, s.Register("daily", "@hourly", 1, run), "job daily already registered")
+	assert.EqualError(t, s.Register("broken", "* *", 1, run), `job broken: cron expression "* *" must have 5 or 6 fields`)
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	now := time.Date(2024, 3, 5, 0, 10, 0, 0, time.UTC)
+
+	t.Run("catch up missed run after last run", func(t *testing.T) {
+		s, jobRunRepoMock := newTestScheduler(t, now)
+
+		ran := []time.Time{}
+		assert.NoError(t, s.Register("report", "5 0 * * *", 31, func(ctx context.Context, scheduledAt time.Time) error {
+			ran = append(ran, scheduledAt)
+			return nil
+		}))
+
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "report").Return(entity.JobRunEntity{ScheduledAt: time.Date(2024, 3, 2, 0, 5, 0, 0, time.UTC)}, nil)
+		for i, day := range []int{3, 4, 5} {
+			scheduledAt := time.Date(2024, 3, day, 0, 5, 0, 0, time.UTC)
+			jobRunRepoMock.EXPECT().Start(gomock.Any(), entity.JobRunEntity{
+				JobName:     "report",
+				ScheduledAt: scheduledAt,
+				StartedAt:   now,
+				Status:      commons.StatusJobRunRunning,
+			}).Return(entity.JobRunEntity{Id: i + 1}, true, nil)
+			jobRunRepoMock.EXPECT().Finish(gomock.Any(), i+1, commons.StatusJobRunSuccess, "", now).Return(nil)
+		}
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 3, 3, 0, 5, 0, 0, time.UTC),
+			time.Date(2024, 3, 4, 0, 5, 0, 0, time.UTC),
+			time.Date(2024, 3, 5, 0, 5, 0, 0, time.UTC),
+		}, ran)
+	})
+
+	t.Run("only latest missed run within catch up", func(t *testing.T) {
+		s, jobRunRepoMock := newTestScheduler(t, now)
+
+		ran := []time.Time{}
+		assert.NoError(t, s.Register("task", "* * * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			ran = append(ran, scheduledAt)
+			return nil
+		}))
+
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "task").Return(entity.JobRunEntity{ScheduledAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, nil)
+		jobRunRepoMock.EXPECT().Start(gomock.Any(), gomock.Any()).Return(entity.JobRunEntity{Id: 1}, true, nil)
+		jobRunRepoMock.EXPECT().Finish(gomock.Any(), 1, commons.StatusJobRunSuccess, "", now).Return(nil)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+
+		assert.Equal(t, []time.Time{now}, ran)
+	})
+
+	t.Run("new job start from scheduler start", func(t *testing.T) {
+		s, jobRunRepoMock := newTestScheduler(t, now)
+		s.startedAt = now.Add(-time.Minute)
+
+		assert.NoError(t, s.Register("report", "5 0 * * *", 31, func(ctx context.Context, scheduledAt time.Time) error {
+			t.Fatal("job should not run")
+			return nil
+		}))
+
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "report").Return(entity.JobRunEntity{}, nil)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+	})
+
+	t.Run("skip run already recorded by other process", func(t *testing.T) {
+		s, jobRunRepoMock := newTestScheduler(t, now)
+
+		assert.NoError(t, s.Register("report", "5 0 * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			t.Fatal("job should not run")
+			return nil
+		}))
+
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "report").Return(entity.JobRunEntity{ScheduledAt: time.Date(2024, 3, 4, 0, 5, 0, 0, time.UTC)}, nil)
+		jobRunRepoMock.EXPECT().Start(gomock.Any(), gomock.Any()).Return(entity.JobRunEntity{}, false, nil)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+	})
+
+	t.Run("record failed and panic run", func(t *testing.T) {
+		s, jobRunRepoMock := newTestScheduler(t, now)
+
+		assert.NoError(t, s.Register("failed", "5 0 * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			return errors.New("database down")
+		}))
+		assert.NoError(t, s.Register("panic", "5 0 * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			panic("nil map")
+		}))
+
+		last := entity.JobRunEntity{ScheduledAt: time.Date(2024, 3, 4, 0, 5, 0, 0, time.UTC)}
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "failed").Return(last, nil)
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "panic").Return(last, nil)
+		jobRunRepoMock.EXPECT().Start(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, data entity.JobRunEntity) (entity.JobRunEntity, bool, error) {
+			if data.JobName == "failed" {
+				return entity.JobRunEntity{Id: 1}, true, nil
+			}
+			return entity.JobRunEntity{Id: 2}, true, nil
+		}).Times(2)
+		jobRunRepoMock.EXPECT().Finish(gomock.Any(), 1, commons.StatusJobRunFailed, "database down", now).Return(nil)
+		jobRunRepoMock.EXPECT().Finish(gomock.Any(), 2, commons.StatusJobRunFailed, "panic: nil map", now).Return(nil)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+	})
+
+	t.Run("no overlap with running job", func(t *testing.T) {
+		s, _ := newTestScheduler(t, now)
+
+		assert.NoError(t, s.Register("task", "* * * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			return nil
+		}))
+		s.jobs[0].running.Store(true)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+	})
+}
+
+func TestScheduler_RunNow(t *testing.T) {
+	now := time.Date(2024, 3, 5, 0, 10, 0, 0, time.UTC)
+
+	t.Run("wait due job to finish", func(t *testing.T) {
+		s, jobRunRepoMock := newTestScheduler(t, now)
+
+		ran := []time.Time{}
+		assert.NoError(t, s.Register("task", "* * * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			time.Sleep(10 * time.Millisecond)
+			ran = append(ran, scheduledAt)
+			return nil
+		}))
+
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "task").Return(entity.JobRunEntity{ScheduledAt: now.Add(-time.Minute)}, nil)
+		jobRunRepoMock.EXPECT().Start(gomock.Any(), gomock.Any()).Return(entity.JobRunEntity{Id: 1}, true, nil)
+		jobRunRepoMock.EXPECT().Finish(gomock.Any(), 1, commons.StatusJobRunSuccess, "", now).Return(nil)
+
+		assert.NoError(t, s.RunNow(context.Background()))
+		assert.Equal(t, []time.Time{now}, ran)
+	})
+
+	t.Run("not run without lease", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		lockRepoMock := mock_repositories.NewMockILockRepository(ctrl)
+		lockRepoMock.EXPECT().Acquire(gomock.Any(), LockName, gomock.Any(), 3*time.Second).Return(false, nil)
+
+		s := NewScheduler(mock_repositories.NewMockIJobRunRepository(ctrl), lockRepoMock, clock.NewSimulated(now), time.Second)
+		assert.NoError(t, s.Register("task", "* * * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+			t.Fatal("job should not run")
+			return nil
+		}))
+
+		assert.ErrorIs(t, s.RunNow(context.Background()), ErrNotLeader)
+	})
+}
+
+func TestScheduler_Lead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRunRepoMock := mock_repositories.NewMockIJobRunRepository(ctrl)
+	lockRepoMock := mock_repositories.NewMockILockRepository(ctrl)
+
+	now := time.Date(2024, 3, 5, 0, 5, 0, 0, time.UTC)
+	s := NewScheduler(jobRunRepoMock, lockRepoMock, clock.NewSimulated(now), time.Second)
+	s.startedAt = now.Add(-time.Minute)
+
+	calls := 0
+	assert.NoError(t, s.Register("every_minute", "* * * * *", 1, func(ctx context.Context, scheduledAt time.Time) error {
+		calls++
+		return nil
+	}))
+
+	t.Run("not run without lease", func(t *testing.T) {
+		lockRepoMock.EXPECT().Acquire(gomock.Any(), LockName, s.owner, 3*time.Second).Return(false, nil)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("not run when lock error", func(t *testing.T) {
+		lockRepoMock.EXPECT().Acquire(gomock.Any(), LockName, s.owner, 3*time.Second).Return(false, errors.New("database down"))
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("take over lease and cancel on lost", func(t *testing.T) {
+		lockRepoMock.EXPECT().Acquire(gomock.Any(), LockName, s.owner, 3*time.Second).Return(true, nil)
+		jobRunRepoMock.EXPECT().GetLast(gomock.Any(), "every_minute").Return(entity.JobRunEntity{}, nil)
+		jobRunRepoMock.EXPECT().Start(gomock.Any(), gomock.Any()).Return(entity.JobRunEntity{Id: 1}, true, nil)
+		jobRunRepoMock.EXPECT().Finish(gomock.Any(), 1, commons.StatusJobRunSuccess, "", gomock.Any()).Return(nil)
+
+		s.Tick(context.Background())
+		s.wg.Wait()
+
+		assert.Equal(t, 1, calls)
+		leaderCtx := s.leaderCtx
+
+		lockRepoMock.EXPECT().Acquire(gomock.Any(), LockName, s.owner, 3*time.Second).Return(false, nil)
+
+		s.Tick(context.Background())
+
+		assert.ErrorIs(t, leaderCtx.Err(), context.Canceled)
+		assert.Nil(t, s.leaderCtx)
+	})
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type CreateBorrowerEntity struct {
+	Username    string
+	FullName    string
+	NationalId  string
+	Phone       string
+	Email       string
+	Address     string
+	DateOfBirth time.Time
+}
+
+type UpdateBorrowerEntity struct {
+	Username    string
+	FullName    string
+	NationalId  string
+	Phone       string
+	Email       string
+	Address     string
+	DateOfBirth time.Time
+}
+
+// ReviewKycEntity is result of kyc review by operator
+type ReviewKycEntity struct {
+	Username  string
+	KycStatus int
+}
+
+func (s *Service) CreateBorrower(ctx context.Context, data CreateBorrowerEntity) (entity.UserEntity, error) {
+	user, err := s.repo.User.GetUser(ctx, data.Username)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+
+	if user.Username != "" {
+		return entity.UserEntity{}, ErrUserAlreadyRegistered
+	}
+
+	// new borrower always start with pending kyc until verified
+	return s.repo.User.Create(ctx, entity.UserEntity{
+		Username:       data.Username,
+		FullName:       data.FullName,
+		NationalId:     data.NationalId,
+		Phone:          data.Phone,
+		Email:          data.Email,
+		Address:        data.Address,
+		DateOfBirth:    data.DateOfBirth,
+		KycStatus:      commons.StatusKycPending,
+		KycSubmittedAt: s.clock.Now(),
+	})
+}
+
+// UpdateBorrower change profile of borrower, kyc status is only changed by ReviewKyc.
+// Verified borrower that change identity go back to pending kyc until reviewed again
+func (s *Service) UpdateBorrower(ctx context.Context, data UpdateBorrowerEntity) (entity.UserEntity, error) {
+	user, err := s.repo.User.GetUser(ctx, data.Username)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+
+	if user.Username == "" {
+		return entity.UserEntity{}, ErrUserNotRegistered
+	}
+
+	identityChanged := data.NationalId != user.NationalId || data.FullName != user.FullName
+	if identityChanged && user.KycStatus == commons.StatusKycVerified {
+		setKycStatus(&user, commons.StatusKycPending, s.clock.Now())
+	}
+
+	user.FullName = data.FullName
+	user.NationalId = data.NationalId
+	user.Phone = data.Phone
+	user.Email = data.Email
+	user.Address = data.Address
+	user.DateOfBirth = data.DateOfBirth
+
+	err = s.repo.User.UpdateProfile(ctx, data.Username, user)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+
+	return user, nil
+}
+
+// ReviewKyc record kyc decision of operator on borrower
+func (s *Service) ReviewKyc(ctx context.Context, data ReviewKycEntity) (entity.UserEntity, error) {
+	user, err := s.repo.User.GetUser(ctx, data.Username)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+
+	if user.Username == "" {
+		return entity.UserEntity{}, ErrUserNotRegistered
+	}
+
+	if !isValidKycStatus(data.KycStatus) {
+		return entity.UserEntity{}, ErrInvalidKycStatus
+	}
+
+	if data.KycStatus != user.KycStatus {
+		setKycStatus(&user, data.KycStatus, s.clock.Now())
+	}
+
+	err = s.repo.User.UpdateProfile(ctx, data.Username, user)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+
+	return user, nil
+}
+
+func (s *Service) GetBorrower(ctx context.Context, username string) (entity.UserEntity, error) {
+	user, err := s.repo.User.GetUser(ctx, username)
+	if err != nil {
+		return entity.UserEntity{}, err
+	}
+
+	if user.Username == "" {
+		return entity.UserEntity{}, ErrUserNotRegistered
+	}
+
+	return user, nil
+}
+
+// setKycStatus record verification time only when kyc status move to verified,
+// any other status drop previous verification
+func setKycStatus(user *entity.UserEntity, status int, now time.Time) {
+	switch status {
+	case commons.StatusKycVerified:
+		user.KycVerifiedAt = now
+	case commons.StatusKycPending:
+		user.KycSubmittedAt = now
+		user.KycVerifiedAt = time.Time{}
+	default:
+		user.KycVerifiedAt = time.Time{}
+	}
+	user.KycStatus = status
+}
+
+func isValidKycStatus(status int) bool {
+	switch status {
+	case commons.StatusKycPending, commons.StatusKycVerified, commons.StatusKycRejected:
+		return true
+	}
+
+	return false
+}
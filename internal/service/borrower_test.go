@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CreateBorrower(t *testing.T) {
+	t.Run("success create borrower", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		data := CreateBorrowerEntity{
+			Username:    "user123",
+			FullName:    "User Satu",
+			NationalId:  "3171000000000001",
+			Phone:       "08123456789",
+			Email:       "user123@mail.com",
+			Address:     "Jakarta",
+			DateOfBirth: time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{}, nil)
+
+		userRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user entity.UserEntity) (entity.UserEntity, error) {
+			assert.Equal(t, commons.StatusKycPending, user.KycStatus)
+			assert.False(t, user.KycSubmittedAt.IsZero())
+			assert.True(t, user.KycVerifiedAt.IsZero())
+
+			user.Status = commons.StatusUserNew
+			return user, nil
+		})
+
+		user, err := service.CreateBorrower(context.Background(), data)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "user123", user.Username)
+		assert.Equal(t, commons.StatusUserNew, user.Status)
+	})
+
+	t.Run("error user already registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+		}, nil)
+
+		_, err := service.CreateBorrower(context.Background(), CreateBorrowerEntity{
+			Username: "user123",
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "user already registered")
+	})
+}
+
+func TestService_UpdateBorrower(t *testing.T) {
+	t.Run("success update contact keep kyc", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		verifiedAt := time.Now().AddDate(0, 0, -1)
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:      "user123",
+			FullName:      "User Satu",
+			NationalId:    "3171000000000001",
+			KycStatus:     commons.StatusKycVerified,
+			KycVerifiedAt: verifiedAt,
+		}, nil)
+
+		userRepoMock.EXPECT().UpdateProfile(gomock.Any(), "user123", gomock.Any()).Return(nil)
+
+		user, err := service.UpdateBorrower(context.Background(), UpdateBorrowerEntity{
+			Username:   "user123",
+			FullName:   "User Satu",
+			NationalId: "3171000000000001",
+			Phone:      "08123456789",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "08123456789", user.Phone)
+		assert.Equal(t, commons.StatusKycVerified, user.KycStatus)
+		assert.Equal(t, verifiedAt, user.KycVerifiedAt)
+	})
+
+	t.Run("success change identity reset kyc to pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:      "user123",
+			FullName:      "User Satu",
+			NationalId:    "3171000000000001",
+			KycStatus:     commons.StatusKycVerified,
+			KycVerifiedAt: time.Now(),
+		}, nil)
+
+		userRepoMock.EXPECT().UpdateProfile(gomock.Any(), "user123", gomock.Any()).DoAndReturn(func(ctx context.Context, username string, user entity.UserEntity) error {
+			assert.Equal(t, commons.StatusKycPending, user.KycStatus)
+			return nil
+		})
+
+		user, err := service.UpdateBorrower(context.Background(), UpdateBorrowerEntity{
+			Username:   "user123",
+			FullName:   "User Satu",
+			NationalId: "3171000000000002",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "3171000000000002", user.NationalId)
+		assert.Equal(t, commons.StatusKycPending, user.KycStatus)
+		assert.True(t, user.KycVerifiedAt.IsZero())
+		assert.False(t, user.KycSubmittedAt.IsZero())
+	})
+
+	t.Run("error user not registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{}, nil)
+
+		_, err := service.UpdateBorrower(context.Background(), UpdateBorrowerEntity{
+			Username: "user123",
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "user not registered")
+	})
+}
+
+func TestService_ReviewKyc(t *testing.T) {
+	t.Run("success verify kyc", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			FullName:  "User Satu",
+			KycStatus: commons.StatusKycPending,
+			Status:    commons.StatusUserNew,
+		}, nil)
+
+		userRepoMock.EXPECT().UpdateProfile(gomock.Any(), "user123", gomock.Any()).Return(nil)
+
+		user, err := service.ReviewKyc(context.Background(), ReviewKycEntity{
+			Username:  "user123",
+			KycStatus: commons.StatusKycVerified,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "User Satu", user.FullName)
+		assert.Equal(t, commons.StatusKycVerified, user.KycStatus)
+		assert.False(t, user.KycVerifiedAt.IsZero())
+	})
+
+	t.Run("success reject kyc drop verification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:      "user123",
+			KycStatus:     commons.StatusKycVerified,
+			KycVerifiedAt: time.Now(),
+		}, nil)
+
+		userRepoMock.EXPECT().UpdateProfile(gomock.Any(), "user123", gomock.Any()).Return(nil)
+
+		user, err := service.ReviewKyc(context.Background(), ReviewKycEntity{
+			Username:  "user123",
+			KycStatus: commons.StatusKycRejected,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, commons.StatusKycRejected, user.KycStatus)
+		assert.True(t, user.KycVerifiedAt.IsZero())
+	})
+
+	t.Run("error invalid kyc status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+		}, nil)
+
+		_, err := service.ReviewKyc(context.Background(), ReviewKycEntity{
+			Username:  "user123",
+			KycStatus: 99,
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "invalid kyc status")
+	})
+
+	t.Run("error user not registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{}, nil)
+
+		_, err := service.ReviewKyc(context.Background(), ReviewKycEntity{
+			Username:  "user123",
+			KycStatus: commons.StatusKycVerified,
+		})
+
+		assert.Equal(t, ErrUserNotRegistered, err)
+	})
+}
+
+func TestService_GetBorrower(t *testing.T) {
+	t.Run("success get borrower", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			FullName: "User Satu",
+		}, nil)
+
+		user, err := service.GetBorrower(context.Background(), "user123")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "User Satu", user.FullName)
+	})
+
+	t.Run("got error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{}, errors.New("any error from repository"))
+
+		_, err := service.GetBorrower(context.Background(), "user123")
+
+		assert.NotNil(t, err)
+	})
+}
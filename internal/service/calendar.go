@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/billing-engine/internal/calendar"
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+func (s *Service) getCalendar(ctx context.Context, country string) (*calendar.Calendar, error) {
+	holidays, err := s.repo.Holiday.GetByCountry(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := []time.Time{}
+	for _, holiday := range holidays {
+		dates = append(dates, holiday.Date)
+	}
+
+	return calendar.NewCalendar(country, dates), nil
+}
+
+// rollSchedule adjust due date of every installment to business day based on product roll convention
+func (s *Service) rollSchedule(ctx context.Context, product entity.LoanProductEntity, payLoans []entity.PayLoanEntity) error {
+	if product.RollConvention == commons.RollConventionNone {
+		return nil
+	}
+
+	cal, err := s.getCalendar(ctx, product.Country)
+	if err != nil {
+		return err
+	}
+
+	for i := range payLoans {
+		payLoans[i].CreatedAt = cal.Roll(payLoans[i].CreatedAt, product.RollConvention)
+	}
+
+	return nil
+}
+
+// dueDateRoller keep product and calendar loaded during one run,
+// so every loan with same product not query them again. Safe to share between goroutine
+type dueDateRoller struct {
+	service   *Service
+	mu        sync.Mutex
+	products  map[string]entity.LoanProductEntity
+	calendars map[string]*calendar.Calendar
+}
+
+func (s *Service) newDueDateRoller() *dueDateRoller {
+	return &dueDateRoller{
+		service:   s,
+		products:  map[string]entity.LoanProductEntity{},
+		calendars: map[string]*calendar.Calendar{},
+	}
+}
+
+func (r *dueDateRoller) product(ctx context.Context, productCode string) (entity.LoanProductEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.loadProduct(ctx, productCode)
+}
+
+func (r *dueDateRoller) loadProduct(ctx context.Context, productCode string) (entity.LoanProductEntity, error) {
+	if product, ok := r.products[productCode]; ok {
+		return product, nil
+	}
+
+	product, err := r.service.getLoanProduct(ctx, productCode)
+	if err != nil {
+		return entity.LoanProductEntity{}, err
+	}
+	r.products[productCode] = product
+
+	return product, nil
+}
+
+// roll return due date of installment after adjusted to business day
+func (r *dueDateRoller) roll(ctx context.Context, productCode string, dueDate time.Time) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, err := r.loadProduct(ctx, productCode)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if product.RollConvention == commons.RollConventionNone {
+		return dueDate, nil
+	}
+
+	cal, ok := r.calendars[product.Country]
+	if !ok {
+		cal, err = r.service.getCalendar(ctx, product.Country)
+		if err != nil {
+			return time.Time{}, err
+		}
+		r.calendars[product.Country] = cal
+	}
+
+	return cal.Roll(dueDate, product.RollConvention), nil
+}
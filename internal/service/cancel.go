@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type CancelLoanEntity struct {
+	Username string
+	Reason   string
+}
+
+// CancelLoan withdraw loan within cooling off period of the product, the schedule is voided
+// and user released back as if the loan never exist. Loan is closed first and only while still open,
+// so loan written off or refinanced in the meantime return ErrLoanNotOpen. Loan with any paid installment
+// is not cancelled as payment would not be given back
+func (s *Service) CancelLoan(ctx context.Context, data CancelLoanEntity) (entity.LoanEntity, error) {
+	if data.Reason == "" {
+		return entity.LoanEntity{}, ErrCancellationReasonMissing
+	}
+
+	loan, err := s.getLoan(ctx, data.Username, commons.StatusLoanNew)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	// old loan was paid off by this loan, cancelling would leave the borrower without any loan
+	if loan.RefinancedFrom != 0 {
+		return entity.LoanEntity{}, ErrRefinanceNotCancellable
+	}
+
+	product, err := s.getLoanProduct(ctx, loan.ProductCode)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	now := s.clock.Now()
+	if now.After(loan.CreatedAt.AddDate(0, 0, product.CoolingOffDays)) {
+		return entity.LoanEntity{}, ErrCoolingOffPassed
+	}
+
+	loan.Status = commons.StatusLoanCancelled
+	loan.ClosedAt = now
+	loan.ClosedReason = data.Reason
+
+	err = s.repo.Transaction.WithTransaction(ctx, func(repo *repository.Repository) error {
+		closed, err := repo.Loan.Close(ctx, loan.Id, loan)
+		if err != nil {
+			return err
+		}
+
+		if !closed {
+			return ErrLoanNotOpen
+		}
+
+		payLoans, err := repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, payLoan := range payLoans {
+			if payLoan.Status == commons.StatusPayLoanPayed {
+				return ErrPaidLoanNotCancellable
+			}
+		}
+
+		err = repo.PayLoan.UpdateStatusByLoanId(ctx, loan.Id, commons.StatusPayLoanUnpayed, commons.StatusPayLoanVoid)
+		if err != nil {
+			return err
+		}
+
+		// cancelled loan never happened, the promo usage is given back
+		if loan.PromoCode != "" {
+			err = repo.Promo.Release(ctx, loan.PromoCode)
+			if err != nil {
+				return err
+			}
+		}
+
+		// user that already finished other loan back to closed loan, otherwise new user
+		closedLoans, err := repo.Loan.CountByUsernameAndStatus(ctx, data.Username, commons.StatusLoanClosed)
+		if err != nil {
+			return err
+		}
+
+		status := commons.StatusUserNew
+		if closedLoans > 0 {
+			status = commons.StatusUserClosedLoan
+		}
+
+		return repo.User.UpdateUser(ctx, data.Username, status)
+	})
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	return loan, nil
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CancelLoan(t *testing.T) {
+	t.Run("success cancel loan of new user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			CreatedAt:   time.Now().AddDate(0, 0, -3),
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			CoolingOffDays: 14,
+		}, nil)
+
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).DoAndReturn(func(ctx context.Context, loanId int, loan entity.LoanEntity) (bool, error) {
+			assert.Equal(t, commons.StatusLoanCancelled, loan.Status)
+			assert.Equal(t, "changed my mind", loan.ClosedReason)
+			return true, nil
+		})
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Status: commons.StatusPayLoanUnpayed},
+			{Id: 2, LoanId: 123, Status: commons.StatusPayLoanUnpayed},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanVoid).Return(nil)
+
+		loaRepoMock.EXPECT().CountByUsernameAndStatus(gomock.Any(), "user123", commons.StatusLoanClosed).Return(int64(0), nil)
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserNew).Return(nil)
+
+		loan, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, commons.StatusLoanCancelled, loan.Status)
+		assert.False(t, loan.ClosedAt.IsZero())
+	})
+
+	t.Run("success cancel loan of returning user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			CreatedAt:   time.Now(),
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			CoolingOffDays: 14,
+		}, nil)
+
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(true, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanVoid).Return(nil)
+		loaRepoMock.EXPECT().CountByUsernameAndStatus(gomock.Any(), "user123", commons.StatusLoanClosed).Return(int64(1), nil)
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserClosedLoan).Return(nil)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("success cancel loan give back promo usage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Promo:       promoRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			PromoCode:   "FREE4",
+			CreatedAt:   time.Now(),
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			CoolingOffDays: 14,
+		}, nil)
+
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(true, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanVoid).Return(nil)
+		promoRepoMock.EXPECT().Release(gomock.Any(), "FREE4").Return(nil)
+		loaRepoMock.EXPECT().CountByUsernameAndStatus(gomock.Any(), "user123", commons.StatusLoanClosed).Return(int64(0), nil)
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserNew).Return(nil)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error loan already has paid installment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			CreatedAt:   time.Now(),
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			CoolingOffDays: 14,
+		}, nil)
+
+		// first installment is due at booking and already paid
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(true, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Status: commons.StatusPayLoanPayed},
+			{Id: 2, LoanId: 123, Status: commons.StatusPayLoanUnpayed},
+		}, nil)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.Equal(t, ErrPaidLoanNotCancellable, err)
+	})
+
+	t.Run("error loan written off before cancel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			Loan:        loaRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			CreatedAt:   time.Now(),
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			CoolingOffDays: 14,
+		}, nil)
+
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(false, nil)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.Equal(t, ErrLoanNotOpen, err)
+	})
+
+	t.Run("error cooling off period passed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan:        loaRepoMock,
+			LoanProduct: loanProductRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+			CreatedAt:   time.Now().AddDate(0, 0, -15),
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			CoolingOffDays: 14,
+		}, nil)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "cooling off period already passed")
+	})
+
+	t.Run("error refinance loan not cancellable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:             124,
+			ProductCode:    commons.DefaultProductCode,
+			RefinancedFrom: 123,
+			CreatedAt:      time.Now(),
+		}, nil)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+			Reason:   "changed my mind",
+		})
+
+		assert.Equal(t, ErrRefinanceNotCancellable, err)
+	})
+
+	t.Run("error reason is required", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.CancelLoan(context.Background(), CancelLoanEntity{
+			Username: "user123",
+		})
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type PortfolioEntity struct {
+	Currency    string
+	LoanCount   int64
+	Amount      float64
+	Outstanding float64
+}
+
+// isSameCurrency check payment currency against loan, currency must be given, empty currency never match
+func isSameCurrency(loan entity.LoanEntity, code string) bool {
+	return code != "" && code == loan.Currency
+}
+
+// roundLoan round amounts of loan to minor unit of its currency
+func roundLoan(loan entity.LoanEntity) entity.LoanEntity {
+	loan.Amount = currency.Round(loan.Currency, loan.Amount)
+	loan.OriginationFee = currency.Round(loan.Currency, loan.OriginationFee)
+	loan.OriginationFeeTax = currency.Round(loan.Currency, loan.OriginationFeeTax)
+	loan.ProcessingFee = currency.Round(loan.Currency, loan.ProcessingFee)
+	loan.DisbursedAmount = currency.Round(loan.Currency, loan.DisbursedAmount)
+	loan.DiscountAmount = currency.Round(loan.Currency, loan.DiscountAmount)
+
+	return loan
+}
+
+// roundPayLoans round amounts of every installment to minor unit of currency
+func roundPayLoans(code string, payLoans []entity.PayLoanEntity) {
+	for i := range payLoans {
+		payLoans[i].Amount = currency.Round(code, payLoans[i].Amount)
+		payLoans[i].FeeAmount = currency.Round(code, payLoans[i].FeeAmount)
+		payLoans[i].TaxAmount = currency.Round(code, payLoans[i].TaxAmount)
+		payLoans[i].WithholdingTaxAmount = currency.Round(code, payLoans[i].WithholdingTaxAmount)
+		payLoans[i].DiscountAmount = currency.Round(code, payLoans[i].DiscountAmount)
+	}
+}
+
+// GetPortfolio sum open loans grouped by currency, amount of different currency never added together
+func (s *Service) GetPortfolio(ctx context.Context) ([]PortfolioEntity, error) {
+	portfolios, err := s.repo.Loan.GetPortfolioByCurrency(ctx, commons.StatusLoanNew)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []PortfolioEntity{}
+	for _, portfolio := range portfolios {
+		result = append(result, PortfolioEntity{
+			Currency:    portfolio.Currency,
+			LoanCount:   portfolio.LoanCount,
+			Amount:      currency.Round(portfolio.Currency, portfolio.Amount),
+			Outstanding: currency.Round(portfolio.Currency, portfolio.Amount-portfolio.PaidAmount),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_QuoteLoan_Currency(t *testing.T) {
+	t.Run("success round schedule to currency without decimals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+		}, clock.System)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "idr").Return(entity.LoanProductEntity{
+			Code:     "idr",
+			Interest: 10,
+			Tenor:    3,
+			AdminFee: 2.5,
+			Currency: "IDR",
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		quote, err := service.QuoteLoan(context.Background(), QuoteLoanEntity{
+			Amount:      1000,
+			ProductCode: "idr",
+		})
+
+		// 366.67 per installment plus 36.67 interest
+		assert.Nil(t, err)
+		assert.Equal(t, "IDR", quote.Loan.Currency)
+		assert.Equal(t, float64(1100), quote.Loan.Amount)
+		assert.Equal(t, float64(403), quote.PayLoans[0].Amount)
+		assert.Equal(t, float64(3), quote.PayLoans[0].FeeAmount)
+	})
+}
+
+func TestService_MakePayment_Currency(t *testing.T) {
+	t.Run("error payment in other currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:       123,
+			Currency: "IDR",
+		}, nil)
+
+		result, err := service.MakePayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   403,
+			Currency: "USD",
+		})
+
+		assert.Nil(t, err)
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PaymentReasonCurrencyMismatch, result.ReasonCode)
+		assert.Equal(t, "payment currency not same with loan currency : IDR", result.Message)
+	})
+
+	t.Run("success payment compared on currency minor unit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:    userRepoMock,
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:       123,
+			Currency: "IDR",
+		}, nil)
+
+		// installment of loan booked before rounding
+		payLoanRepoMock.EXPECT().GetInSpecificTimeAndStatus(gomock.Any(), 123, gomock.Any()).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 403.3333, Status: commons.StatusPayLoanUnpayed},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().Update(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, data entity.PayLoanEntity) error {
+			assert.Equal(t, commons.StatusPayLoanPayed, data.Status)
+			assert.False(t, data.PaidAt.IsZero())
+			return nil
+		})
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 403.3333, Status: commons.StatusPayLoanPayed},
+		}, nil)
+
+		result, err := service.MakePayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   403,
+			Currency: "IDR",
+		})
+
+		assert.Nil(t, err)
+		assert.True(t, result.Accepted)
+	})
+}
+
+func TestService_MakePayment_CurrencyRequired(t *testing.T) {
+	service := NewService(&repository.Repository{}, clock.System)
+
+	_, err := service.MakePayment(context.Background(), MakePaymentEntity{
+		Username: "user123",
+		Amount:   403,
+	})
+
+	assert.Equal(t, ErrCurrencyRequired, err)
+}
+
+func TestService_MakeRecoveryPayment_Currency(t *testing.T) {
+	t.Run("error recovery in other currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanChargedOff).Return(entity.LoanEntity{
+			Id:       123,
+			Currency: "IDR",
+		}, nil)
+
+		_, err := service.MakeRecoveryPayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   500,
+			Currency: "USD",
+		})
+
+		assert.EqualError(t, err, "payment currency not same with loan currency : IDR")
+	})
+
+	t.Run("error recovery without currency", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.MakeRecoveryPayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   500,
+		})
+
+		assert.Equal(t, ErrCurrencyRequired, err)
+	})
+}
+
+func TestService_GetPortfolio(t *testing.T) {
+	t.Run("success get portfolio per currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetPortfolioByCurrency(gomock.Any(), commons.StatusLoanNew).Return([]entity.PortfolioEntity{
+			{Currency: "IDR", LoanCount: 2, Amount: 110000000, PaidAmount: 5500000},
+			{Currency: "USD", LoanCount: 1, Amount: 1100.505, PaidAmount: 100.1},
+		}, nil)
+
+		portfolios, err := service.GetPortfolio(context.Background())
+
+		assert.Nil(t, err)
+		assert.Len(t, portfolios, 2)
+		assert.Equal(t, float64(104500000), portfolios[0].Outstanding)
+		assert.Equal(t, 1000.41, portfolios[1].Outstanding)
+	})
+
+	t.Run("error get portfolio", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetPortfolioByCurrency(gomock.Any(), commons.StatusLoanNew).Return(nil, errors.New("error"))
+
+		_, err := service.GetPortfolio(context.Background())
+
+		assert.EqualError(t, err, "error")
+	})
+}
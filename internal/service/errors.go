@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// ErrorKind classify domain error so caller can react without matching the message
+type ErrorKind int
+
+const (
+	ErrorKindNotFound ErrorKind = iota + 1
+	ErrorKindConflict
+	ErrorKindValidation
+	ErrorKindBusinessRule
+)
+
+// Error is domain error returned by service with stable machine readable code
+// Field is set when error is caused by one field of the request
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Field   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(kind ErrorKind, code string, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func newFieldError(kind ErrorKind, code string, field string, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Field:   field,
+		Message: message,
+	}
+}
+
+var (
+	ErrUserNotRegistered     = newError(ErrorKindNotFound, "USER_NOT_REGISTERED", "user not registered")
+	ErrLoanNotFound          = newError(ErrorKindNotFound, "LOAN_NOT_FOUND", "loan not found")
+	ErrLoanProductNotFound   = newError(ErrorKindNotFound, "LOAN_PRODUCT_NOT_FOUND", "loan product not found")
+	ErrPromoNotFound         = newError(ErrorKindNotFound, "PROMO_NOT_FOUND", "promo code not found")
+	ErrUserAlreadyRegistered = newError(ErrorKindConflict, "USER_ALREADY_REGISTERED", "user already registered")
+	ErrUserHasActiveLoan     = newError(ErrorKindConflict, "USER_HAS_ACTIVE_LOAN", "user have other active loan")
+	ErrLoanNotOpen           = newError(ErrorKindConflict, "LOAN_NOT_OPEN", "loan is no longer open")
+
+	ErrInvalidKycStatus          = newFieldError(ErrorKindValidation, "INVALID_KYC_STATUS", "kyc_status", "invalid kyc status")
+	ErrCancellationReasonMissing = newFieldError(ErrorKindValidation, "CANCELLATION_REASON_REQUIRED", "reason", "cancellation reason is required")
+	ErrWriteOffReasonMissing     = newFieldError(ErrorKindValidation, "WRITE_OFF_REASON_REQUIRED", "reason", "write off reason is required")
+	ErrInvalidPeriods            = newFieldError(ErrorKindValidation, "INVALID_PERIODS", "periods", "periods must be greater than zero")
+	ErrInvalidInterestHandling   = newFieldError(ErrorKindValidation, "INVALID_INTEREST_HANDLING", "interest_handling", "invalid interest handling")
+	ErrInvalidTenor              = newFieldError(ErrorKindValidation, "INVALID_TENOR", "tenor", "tenor must be greater than zero")
+	ErrInvalidHolidayPeriods     = newFieldError(ErrorKindValidation, "INVALID_HOLIDAY_PERIODS", "holiday_periods", "holiday periods can not be negative")
+	ErrInvalidDateRange          = newError(ErrorKindValidation, "INVALID_DATE_RANGE", "from must be before to")
+	ErrInvalidSort               = newFieldError(ErrorKindValidation, "INVALID_SORT", "sort", "sort must be asc or desc")
+	ErrInvalidCursor             = newFieldError(ErrorKindValidation, "INVALID_CURSOR", "cursor", "invalid cursor")
+	ErrInvalidAmount             = newFieldError(ErrorKindValidation, "INVALID_AMOUNT", "amount", "amount must be greater than zero")
+	ErrCurrencyRequired          = newFieldError(ErrorKindValidation, "CURRENCY_REQUIRED", "currency", "currency is required")
+	ErrInvalidExportDataset      = newFieldError(ErrorKindValidation, "INVALID_EXPORT_DATASET", "dataset", "dataset must be loans, schedules, payments or aging")
+	ErrInvalidExportFormat       = newFieldError(ErrorKindValidation, "INVALID_EXPORT_FORMAT", "format", "format must be csv or xlsx")
+	ErrInvalidStatementFormat    = newFieldError(ErrorKindValidation, "INVALID_STATEMENT_FORMAT", "format", "format must be html or pdf")
+
+	ErrKycNotVerified          = newError(ErrorKindBusinessRule, "KYC_NOT_VERIFIED", "user kyc not verified")
+	ErrUserNotActiveLoan       = newError(ErrorKindBusinessRule, "USER_NOT_ACTIVE_LOAN", "user not active loan")
+	ErrUserNotOnOpenLoan       = newError(ErrorKindBusinessRule, "USER_NOT_ON_OPEN_LOAN", "user not on open loan")
+	ErrCoolingOffPassed        = newError(ErrorKindBusinessRule, "COOLING_OFF_PASSED", "cooling off period already passed")
+	ErrNoUpcomingInstallment   = newError(ErrorKindBusinessRule, "NO_UPCOMING_INSTALLMENT", "loan not have upcoming installment")
+	ErrNoRemainingBalance      = newError(ErrorKindBusinessRule, "NO_REMAINING_BALANCE", "loan not have remaining balance")
+	ErrAmountBelowOutstanding  = newError(ErrorKindBusinessRule, "AMOUNT_BELOW_OUTSTANDING", "amount must be greater than outstanding")
+	ErrRefinanceNotCancellable = newError(ErrorKindBusinessRule, "REFINANCE_NOT_CANCELLABLE", "refinance loan can not be cancelled")
+	ErrPaidLoanNotCancellable  = newError(ErrorKindBusinessRule, "PAID_LOAN_NOT_CANCELLABLE", "loan with paid installment can not be cancelled")
+
+	ErrPromoNotActive          = newError(ErrorKindBusinessRule, "PROMO_NOT_ACTIVE", "promo code not valid at this time")
+	ErrPromoUsageLimitReached  = newError(ErrorKindBusinessRule, "PROMO_USAGE_LIMIT_REACHED", "promo code usage limit reached")
+	ErrPromoProductNotEligible = newError(ErrorKindBusinessRule, "PROMO_NOT_ELIGIBLE", "promo code not eligible for loan product")
+	ErrPromoAmountNotEligible  = newError(ErrorKindBusinessRule, "PROMO_NOT_ELIGIBLE", "promo code not eligible for loan amount")
+	ErrPromoRequireBorrower    = newError(ErrorKindBusinessRule, "PROMO_NOT_ELIGIBLE", "promo code require borrower")
+	ErrPromoNewBorrowerOnly    = newError(ErrorKindBusinessRule, "PROMO_NOT_ELIGIBLE", "promo code only for new borrower")
+)
+
+func errAmountBelowMinimum(product entity.LoanProductEntity) *Error {
+	return newFieldError(ErrorKindValidation, "AMOUNT_BELOW_MINIMUM", "amount", fmt.Sprintf("amount must be at least %.*f for product %s", currency.Decimals(product.Currency), product.MinAmount, product.Code))
+}
+
+func errAmountAboveMaximum(product entity.LoanProductEntity) *Error {
+	return newFieldError(ErrorKindValidation, "AMOUNT_ABOVE_MAXIMUM", "amount", fmt.Sprintf("amount must be at most %.*f for product %s", currency.Decimals(product.Currency), product.MaxAmount, product.Code))
+}
+
+func errAmountPrecision(product entity.LoanProductEntity) *Error {
+	return newFieldError(ErrorKindValidation, "AMOUNT_PRECISION", "amount", fmt.Sprintf("amount must have at most %d decimal places for currency %s", currency.Decimals(product.Currency), product.Currency))
+}
+
+func errRecoveryAboveRemaining(currencyCode string, remaining float64) *Error {
+	return newFieldError(ErrorKindValidation, "RECOVERY_ABOVE_REMAINING", "amount", fmt.Sprintf("amount must be at most %.*f remaining written off balance", currency.Decimals(currencyCode), remaining))
+}
+
+func errInvalidExportColumn(err error) *Error {
+	return newFieldError(ErrorKindValidation, "INVALID_EXPORT_COLUMN", "columns", err.Error())
+}
+
+func errCurrencyMismatch(currency string) *Error {
+	return newError(ErrorKindBusinessRule, "CURRENCY_MISMATCH", fmt.Sprintf("payment currency not same with loan currency : %s", currency))
+}
+
+func errProductCurrencyMismatch(currency string) *Error {
+	return newFieldError(ErrorKindBusinessRule, "CURRENCY_MISMATCH", "product_code", fmt.Sprintf("product currency not same with loan currency : %s", currency))
+}
+
+// getLoan return loan of user with given status, missing loan is reported as ErrLoanNotFound
+func (s *Service) getLoan(ctx context.Context, username string, status int) (entity.LoanEntity, error) {
+	loan, err := s.repo.Loan.Get(ctx, username, status)
+	if errors.Is(err, repository.ErrNotFound) {
+		return entity.LoanEntity{}, ErrLoanNotFound
+	}
+
+	return loan, err
+}
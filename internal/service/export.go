@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/billing-engine/internal/export"
+	"github.com/billing-engine/internal/reporting"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// dataset can be exported
+const (
+	ExportLoans     = "loans"
+	ExportSchedules = "schedules"
+	ExportPayments  = "payments"
+	ExportAging     = "aging"
+)
+
+// ExportQuery select dataset, format and columns of export. From and To filter loan by booked time,
+// schedule by due date and payment by paid time, aging is reported as of To (default now)
+type ExportQuery struct {
+	Dataset string
+	Format  string
+	Columns []string
+	From    time.Time
+	To      time.Time
+}
+
+var loanColumns = []export.Column[entity.LoanEntity]{
+	{Name: "id", Value: func(row entity.LoanEntity) any { return row.Id }},
+	{Name: "username", Value: func(row entity.LoanEntity) any { return row.Username }},
+	{Name: "product_code", Value: func(row entity.LoanEntity) any { return row.ProductCode }},
+	{Name: "currency", Value: func(row entity.LoanEntity) any { return row.Currency }},
+	{Name: "amount", Value: func(row entity.LoanEntity) any { return row.Amount }},
+	{Name: "status", Value: func(row entity.LoanEntity) any { return row.Status }},
+	{Name: "created_at", Value: func(row entity.LoanEntity) any { return row.CreatedAt }},
+	{Name: "origination_fee", Value: func(row entity.LoanEntity) any { return row.OriginationFee }},
+	{Name: "processing_fee", Value: func(row entity.LoanEntity) any { return row.ProcessingFee }},
+	{Name: "origination_fee_tax", Value: func(row entity.LoanEntity) any { return row.OriginationFeeTax }},
+	{Name: "disbursed_amount", Value: func(row entity.LoanEntity) any { return row.DisbursedAmount }},
+	{Name: "promo_code", Value: func(row entity.LoanEntity) any { return row.PromoCode }},
+	{Name: "discount_amount", Value: func(row entity.LoanEntity) any { return row.DiscountAmount }},
+	{Name: "written_off_principal", Value: func(row entity.LoanEntity) any { return row.WrittenOffPrincipal }},
+	{Name: "written_off_interest", Value: func(row entity.LoanEntity) any { return row.WrittenOffInterest }},
+	{Name: "written_off_at", Value: func(row entity.LoanEntity) any { return row.WrittenOffAt }},
+	{Name: "recovered_amount", Value: func(row entity.LoanEntity) any { return row.RecoveredAmount }},
+	{Name: "closed_at", Value: func(row entity.LoanEntity) any { return row.ClosedAt }},
+	{Name: "closed_reason", Value: func(row entity.LoanEntity) any { return row.ClosedReason }},
+	{Name: "refinanced_from", Value: func(row entity.LoanEntity) any { return row.RefinancedFrom }},
+}
+
+var installmentColumns = []export.Column[entity.InstallmentRowEntity]{
+	{Name: "id", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.Id }},
+	{Name: "loan_id", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.LoanId }},
+	{Name: "username", Value: func(row entity.InstallmentRowEntity) any { return row.Username }},
+	{Name: "currency", Value: func(row entity.InstallmentRowEntity) any { return row.Currency }},
+	{Name: "due_date", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.CreatedAt }},
+	{Name: "amount", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.Amount }},
+	{Name: "fee_amount", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.FeeAmount }},
+	{Name: "tax_amount", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.TaxAmount }},
+	{Name: "withholding_tax_amount", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.WithholdingTaxAmount }},
+	{Name: "discount_amount", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.DiscountAmount }},
+	{Name: "status", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.Status }},
+	{Name: "paid_at", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.PaidAt }},
+	{Name: "paid_amount", Value: func(row entity.InstallmentRowEntity) any { return row.PayLoan.PaidAmount }},
+}
+
+// agingRow is exposure of one loan with its days past due as of report time
+type agingRow struct {
+	Exposure    entity.ExposureEntity
+	DaysPastDue int
+}
+
+var agingColumns = []export.Column[agingRow]{
+	{Name: "loan_id", Value: func(row agingRow) any { return row.Exposure.LoanId }},
+	{Name: "username", Value: func(row agingRow) any { return row.Exposure.Username }},
+	{Name: "currency", Value: func(row agingRow) any { return row.Exposure.Currency }},
+	{Name: "outstanding", Value: func(row agingRow) any { return row.Exposure.Outstanding }},
+	{Name: "overdue_count", Value: func(row agingRow) any { return row.Exposure.OverdueCount }},
+	{Name: "oldest_due_date", Value: func(row agingRow) any { return row.Exposure.OldestDueDate }},
+	{Name: "days_past_due", Value: func(row agingRow) any { return row.DaysPastDue }},
+	{Name: "bucket", Value: func(row agingRow) any { return reporting.AgingBucket(row.DaysPastDue) }},
+}
+
+// ValidateExport check export query before any row written, so caller still can respond with error
+func (s *Service) ValidateExport(query ExportQuery) error {
+	if query.Format != export.FormatCSV && query.Format != export.FormatXLSX {
+		return ErrInvalidExportFormat
+	}
+
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return ErrInvalidDateRange
+	}
+
+	var err error
+	switch query.Dataset {
+	case ExportLoans:
+		_, err = export.SelectColumns(loanColumns, query.Columns)
+	case ExportSchedules, ExportPayments:
+		_, err = export.SelectColumns(installmentColumns, query.Columns)
+	case ExportAging:
+		_, err = export.SelectColumns(agingColumns, query.Columns)
+	default:
+		return ErrInvalidExportDataset
+	}
+	if err != nil {
+		return errInvalidExportColumn(err)
+	}
+
+	return nil
+}
+
+// Export write dataset row by row to w in requested format
+func (s *Service) Export(ctx context.Context, query ExportQuery, w io.Writer) error {
+	if err := s.ValidateExport(query); err != nil {
+		return err
+	}
+
+	writer, err := export.NewWriter(query.Format, w, query.Dataset)
+	if err != nil {
+		return err
+	}
+
+	filter := entity.ExportFilter{
+		From: query.From,
+		To:   query.To,
+	}
+
+	switch query.Dataset {
+	case ExportLoans:
+		err = writeExport(writer, loanColumns, query.Columns, func(fn func(row entity.LoanEntity) error) error {
+			return s.repo.Loan.StreamLoans(ctx, filter, fn)
+		})
+	case ExportSchedules:
+		err = writeExport(writer, installmentColumns, query.Columns, func(fn func(row entity.InstallmentRowEntity) error) error {
+			return s.repo.PayLoan.StreamSchedules(ctx, filter, fn)
+		})
+	case ExportPayments:
+		err = writeExport(writer, installmentColumns, query.Columns, func(fn func(row entity.InstallmentRowEntity) error) error {
+			return s.repo.PayLoan.StreamPayments(ctx, filter, fn)
+		})
+	case ExportAging:
+		asOf := query.To
+		if asOf.IsZero() {
+			asOf = s.clock.Now()
+		}
+
+		err = writeExport(writer, agingColumns, query.Columns, func(fn func(row agingRow) error) error {
+			return s.repo.PayLoan.StreamExposures(ctx, asOf, func(data entity.ExposureEntity) error {
+				return fn(agingRow{
+					Exposure:    data,
+					DaysPastDue: reporting.DaysPastDue(asOf, convertExposure(data)),
+				})
+			})
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func writeExport[T any](writer export.Writer, columns []export.Column[T], names []string, stream func(fn func(row T) error) error) error {
+	columns, err := export.SelectColumns(columns, names)
+	if err != nil {
+		return errInvalidExportColumn(err)
+	}
+
+	if err := writer.WriteHeader(export.Header(columns)); err != nil {
+		return err
+	}
+
+	return stream(func(row T) error {
+		return writer.WriteRow(export.Row(columns, row))
+	})
+}
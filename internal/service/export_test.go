@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ValidateExport(t *testing.T) {
+	service := NewService(&repository.Repository{}, clock.System)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query ExportQuery
+		err   error
+	}{
+		{name: "valid", query: ExportQuery{Dataset: ExportPayments, Format: "xlsx", Columns: []string{"loan_id", "paid_amount"}, From: from, To: to}},
+		{name: "invalid dataset", query: ExportQuery{Dataset: "users", Format: "csv"}, err: ErrInvalidExportDataset},
+		{name: "invalid format", query: ExportQuery{Dataset: ExportLoans, Format: "pdf"}, err: ErrInvalidExportFormat},
+		{name: "invalid date range", query: ExportQuery{Dataset: ExportLoans, Format: "csv", From: to, To: from}, err: ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, service.ValidateExport(tt.query))
+		})
+	}
+
+	t.Run("invalid column", func(t *testing.T) {
+		err := service.ValidateExport(ExportQuery{Dataset: ExportAging, Format: "csv", Columns: []string{"bucket", "paid_at"}})
+
+		var serviceErr *Error
+		assert.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, "INVALID_EXPORT_COLUMN", serviceErr.Code)
+		assert.Equal(t, "columns", serviceErr.Field)
+		assert.Equal(t, "unknown column paid_at", serviceErr.Message)
+	})
+}
+
+func TestService_Export(t *testing.T) {
+	t.Run("success export loans", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loanRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loanRepoMock,
+		}, clock.System)
+
+		filter := entity.ExportFilter{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		loanRepoMock.EXPECT().StreamLoans(gomock.Any(), filter, gomock.Any()).DoAndReturn(func(ctx context.Context, filter entity.ExportFilter, fn func(data entity.LoanEntity) error) error {
+			for _, loan := range []entity.LoanEntity{
+				{Id: 1, Username: "bambang", Currency: "IDR", Amount: 5500000, CreatedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+				{Id: 2, Username: "joko", Currency: "USD", Amount: 1100.5, CreatedAt: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)},
+			} {
+				if err := fn(loan); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		var buf bytes.Buffer
+		err := service.Export(context.Background(), ExportQuery{
+			Dataset: ExportLoans,
+			Format:  "csv",
+			Columns: []string{"id", "username", "amount", "created_at"},
+			From:    filter.From,
+		}, &buf)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "id,username,amount,created_at\n1,bambang,5500000,2024-01-02 10:00:00\n2,joko,1100.5,2024-01-03 10:00:00\n", buf.String())
+	})
+
+	t.Run("success export aging", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		asOf := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+		payLoanRepoMock.EXPECT().StreamExposures(gomock.Any(), asOf, gomock.Any()).DoAndReturn(func(ctx context.Context, asOf time.Time, fn func(data entity.ExposureEntity) error) error {
+			return fn(entity.ExposureEntity{LoanId: 1, Username: "bambang", Currency: "IDR", Outstanding: 3000000, OverdueCount: 3, OldestDueDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+		})
+
+		var buf bytes.Buffer
+		err := service.Export(context.Background(), ExportQuery{
+			Dataset: ExportAging,
+			Format:  "csv",
+			Columns: []string{"loan_id", "outstanding", "days_past_due", "bucket"},
+			To:      asOf,
+		}, &buf)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "loan_id,outstanding,days_past_due,bucket\n1,3000000,61,61-90\n", buf.String())
+	})
+
+	t.Run("error stream payments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		payLoanRepoMock.EXPECT().StreamPayments(gomock.Any(), entity.ExportFilter{}, gomock.Any()).Return(errors.New("error"))
+
+		var buf bytes.Buffer
+		err := service.Export(context.Background(), ExportQuery{Dataset: ExportPayments, Format: "xlsx"}, &buf)
+
+		assert.EqualError(t, err, "error")
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/tax"
+)
+
+// originationCharge is origination fee and its tax, principal that booked on the loan
+// and amount that disbursed to borrower
+type originationCharge struct {
+	Fee       float64
+	Tax       float64
+	Principal float64
+	Disbursed float64
+}
+
+// newOriginationCharge charge origination fee and its tax based on product origination fee type
+func newOriginationCharge(product entity.LoanProductEntity, principal float64, t *tax.Tax) originationCharge {
+	fee := principal * product.OriginationFee / 100
+	feeTax := t.Vat(fee)
+
+	if product.OriginationFeeType == commons.OriginationFeeFinanced {
+		return originationCharge{
+			Fee:       fee,
+			Tax:       feeTax,
+			Principal: principal + fee + feeTax,
+			Disbursed: principal,
+		}
+	}
+
+	return originationCharge{
+		Fee:       fee,
+		Tax:       feeTax,
+		Principal: principal,
+		Disbursed: principal - fee - feeTax,
+	}
+}
+
+// addInstallmentFees charge admin fee on every installment and processing fee on the first installment
+func addInstallmentFees(product entity.LoanProductEntity, payLoans []entity.PayLoanEntity) {
+	for i := range payLoans {
+		payLoans[i].FeeAmount += product.AdminFee
+	}
+
+	if len(payLoans) > 0 {
+		payLoans[0].FeeAmount += product.ProcessingFee
+	}
+}
+
+// installmentDue return amount that borrower must pay for installment
+func installmentDue(payLoan entity.PayLoanEntity) float64 {
+	return payLoan.Amount + payLoan.FeeAmount + payLoan.TaxAmount
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+const (
+	DefaultHistoryLimit = 20
+	MaxHistoryLimit     = 100
+
+	HistorySortAsc  = "asc"
+	HistorySortDesc = "desc"
+)
+
+// HistoryQuery select page of history of user. Cursor is next cursor of previous page, empty for first page.
+// Sort is asc (oldest first) or desc (newest first) by created date of loan or paid date of payment
+type HistoryQuery struct {
+	Username string
+	Statuses []int
+	From     time.Time
+	To       time.Time
+	Cursor   string
+	Limit    int
+	Sort     string
+}
+
+// LoanHistoryEntity is page of loan, NextCursor is empty on last page
+type LoanHistoryEntity struct {
+	Loans      []entity.LoanEntity
+	NextCursor string
+}
+
+// PaymentHistoryEntity is page of installment, NextCursor is empty on last page
+type PaymentHistoryEntity struct {
+	Payments   []entity.PayLoanEntity
+	NextCursor string
+}
+
+// ListLoans return every loan user ever had, page by page
+func (s *Service) ListLoans(ctx context.Context, query HistoryQuery) (LoanHistoryEntity, error) {
+	filter, err := s.historyFilter(ctx, query)
+	if err != nil {
+		return LoanHistoryEntity{}, err
+	}
+
+	loans, err := s.repo.Loan.ListByUsername(ctx, filter)
+	if err != nil {
+		return LoanHistoryEntity{}, err
+	}
+
+	// one more row than limit is fetched to know there is next page
+	history := LoanHistoryEntity{Loans: loans}
+	if len(loans) > filter.Limit-1 {
+		history.Loans = loans[:filter.Limit-1]
+		last := history.Loans[len(history.Loans)-1]
+		history.NextCursor = encodeCursor(entity.HistoryCursor{Time: last.CreatedAt, Id: last.Id})
+	}
+
+	return history, nil
+}
+
+// ListPayments return paid installment of every loan of user by paid time, page by page. Status is not filtered
+// as every payment is paid installment
+func (s *Service) ListPayments(ctx context.Context, query HistoryQuery) (PaymentHistoryEntity, error) {
+	query.Statuses = nil
+
+	filter, err := s.historyFilter(ctx, query)
+	if err != nil {
+		return PaymentHistoryEntity{}, err
+	}
+
+	payLoans, err := s.repo.PayLoan.ListByUsername(ctx, filter)
+	if err != nil {
+		return PaymentHistoryEntity{}, err
+	}
+
+	history := PaymentHistoryEntity{Payments: payLoans}
+	if len(payLoans) > filter.Limit-1 {
+		history.Payments = payLoans[:filter.Limit-1]
+		last := history.Payments[len(history.Payments)-1]
+		history.NextCursor = encodeCursor(entity.HistoryCursor{Time: last.PaidAt, Id: last.Id})
+	}
+
+	return history, nil
+}
+
+func (s *Service) historyFilter(ctx context.Context, query HistoryQuery) (entity.HistoryFilter, error) {
+	user, err := s.repo.User.GetUser(ctx, query.Username)
+	if err != nil {
+		return entity.HistoryFilter{}, err
+	}
+
+	if user.Username == "" {
+		return entity.HistoryFilter{}, ErrUserNotRegistered
+	}
+
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return entity.HistoryFilter{}, ErrInvalidDateRange
+	}
+
+	if query.Sort != "" && query.Sort != HistorySortAsc && query.Sort != HistorySortDesc {
+		return entity.HistoryFilter{}, ErrInvalidSort
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	if limit > MaxHistoryLimit {
+		limit = MaxHistoryLimit
+	}
+
+	filter := entity.HistoryFilter{
+		Username:   query.Username,
+		Statuses:   query.Statuses,
+		From:       query.From,
+		To:         query.To,
+		Limit:      limit + 1,
+		Descending: query.Sort == HistorySortDesc,
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return entity.HistoryFilter{}, ErrInvalidCursor
+		}
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+// encodeCursor keep time and id of last row as opaque string for client
+func encodeCursor(cursor entity.HistoryCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", cursor.Time.Unix(), cursor.Id)))
+}
+
+func decodeCursor(encoded string) (entity.HistoryCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return entity.HistoryCursor{}, err
+	}
+
+	unix, id, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return entity.HistoryCursor{}, fmt.Errorf("invalid cursor %s", encoded)
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return entity.HistoryCursor{}, err
+	}
+
+	lastId, err := strconv.Atoi(id)
+	if err != nil {
+		return entity.HistoryCursor{}, err
+	}
+
+	return entity.HistoryCursor{Time: time.Unix(seconds, 0).UTC(), Id: lastId}, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ListLoans(t *testing.T) {
+	t.Run("success first page with next cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		createdAt := time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{Username: "user123"}, nil)
+		loaRepoMock.EXPECT().ListByUsername(gomock.Any(), entity.HistoryFilter{
+			Username:   "user123",
+			Statuses:   []int{commons.StatusLoanClosed},
+			Limit:      3,
+			Descending: true,
+		}).Return([]entity.LoanEntity{
+			{Id: 3, CreatedAt: createdAt.AddDate(0, 1, 0)},
+			{Id: 2, CreatedAt: createdAt},
+			{Id: 1, CreatedAt: createdAt.AddDate(0, -1, 0)},
+		}, nil)
+
+		history, err := service.ListLoans(context.Background(), HistoryQuery{
+			Username: "user123",
+			Statuses: []int{commons.StatusLoanClosed},
+			Limit:    2,
+			Sort:     HistorySortDesc,
+		})
+
+		assert.Nil(t, err)
+		assert.Len(t, history.Loans, 2)
+		assert.NotEmpty(t, history.NextCursor)
+
+		cursor, err := decodeCursor(history.NextCursor)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.HistoryCursor{Time: createdAt, Id: 2}, cursor)
+	})
+
+	t.Run("success last page continue after cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		after := entity.HistoryCursor{Time: time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC), Id: 2}
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{Username: "user123"}, nil)
+		loaRepoMock.EXPECT().ListByUsername(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter entity.HistoryFilter) ([]entity.LoanEntity, error) {
+			assert.Equal(t, &after, filter.After)
+			assert.Equal(t, DefaultHistoryLimit+1, filter.Limit)
+			assert.False(t, filter.Descending)
+			return []entity.LoanEntity{{Id: 3}}, nil
+		})
+
+		history, err := service.ListLoans(context.Background(), HistoryQuery{
+			Username: "user123",
+			Cursor:   encodeCursor(after),
+		})
+
+		assert.Nil(t, err)
+		assert.Len(t, history.Loans, 1)
+		assert.Empty(t, history.NextCursor)
+	})
+
+	t.Run("error invalid query", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query HistoryQuery
+			err   error
+		}{
+			{name: "invalid cursor", query: HistoryQuery{Username: "user123", Cursor: "not-a-cursor"}, err: ErrInvalidCursor},
+			{name: "invalid sort", query: HistoryQuery{Username: "user123", Sort: "newest"}, err: ErrInvalidSort},
+			{name: "invalid date range", query: HistoryQuery{Username: "user123", From: time.Now(), To: time.Now().AddDate(0, 0, -1)}, err: ErrInvalidDateRange},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+				service := NewService(&repository.Repository{
+					User: userRepoMock,
+				}, clock.System)
+
+				userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{Username: "user123"}, nil)
+
+				_, err := service.ListLoans(context.Background(), tt.query)
+
+				assert.ErrorIs(t, err, tt.err)
+			})
+		}
+	})
+
+	t.Run("error user not registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{}, nil)
+
+		_, err := service.ListLoans(context.Background(), HistoryQuery{Username: "user123"})
+
+		assert.ErrorIs(t, err, ErrUserNotRegistered)
+	})
+}
+
+func TestService_ListPayments(t *testing.T) {
+	t.Run("success page by paid time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:    userRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{Username: "user123"}, nil)
+		payLoanRepoMock.EXPECT().ListByUsername(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter entity.HistoryFilter) ([]entity.PayLoanEntity, error) {
+			assert.Empty(t, filter.Statuses)
+			return []entity.PayLoanEntity{
+				{Id: 1, LoanId: 123, Status: commons.StatusPayLoanPayed, CreatedAt: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), PaidAt: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)},
+				{Id: 2, LoanId: 123, Status: commons.StatusPayLoanPayed, CreatedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), PaidAt: time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC)},
+			}, nil
+		})
+
+		history, err := service.ListPayments(context.Background(), HistoryQuery{Username: "user123", Statuses: []int{commons.StatusPayLoanUnpayed}, Limit: 1})
+
+		assert.Nil(t, err)
+		assert.Len(t, history.Payments, 1)
+		assert.Equal(t, encodeCursor(entity.HistoryCursor{Time: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC), Id: 1}), history.NextCursor)
+	})
+}
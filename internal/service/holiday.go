@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type PaymentHolidayEntity struct {
+	Username string
+	// number of upcoming installments skipped, the schedule pushed by this periods
+	Periods int
+	// how interest during holiday handled (waived, accrued, capitalized)
+	InterestHandling int
+}
+
+func (s *Service) GrantPaymentHoliday(ctx context.Context, data PaymentHolidayEntity) ([]entity.PayLoanEntity, error) {
+	if data.Periods <= 0 {
+		return nil, ErrInvalidPeriods
+	}
+
+	if !isValidInterestHandling(data.InterestHandling) {
+		return nil, ErrInvalidInterestHandling
+	}
+
+	user, err := s.repo.User.GetUser(ctx, data.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != commons.StatusUserActiveLoan {
+		return nil, ErrUserNotOnOpenLoan
+	}
+
+	loan, err := s.getLoan(ctx, data.Username, commons.StatusLoanNew)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.getLoanProduct(ctx, loan.ProductCode)
+	if err != nil {
+		return nil, err
+	}
+
+	payLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// only installment not yet due can be skipped, arrears stay as is
+	now := s.clock.Now()
+	upcoming := []entity.PayLoanEntity{}
+	for _, payLoan := range payLoans {
+		if payLoan.Status == commons.StatusPayLoanUnpayed && !payLoan.CreatedAt.Before(now) {
+			upcoming = append(upcoming, payLoan)
+		}
+	}
+
+	if len(upcoming) == 0 {
+		return nil, ErrNoUpcomingInstallment
+	}
+
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].CreatedAt.Before(upcoming[j].CreatedAt)
+	})
+
+	shift := commons.DifferentTime * time.Duration(data.Periods)
+	holidayInterest := currency.Round(loan.Currency, interestPortion(upcoming[0].Amount, product.Interest)*float64(data.Periods))
+	for i := range upcoming {
+		upcoming[i].CreatedAt = upcoming[i].CreatedAt.Add(shift)
+
+		if data.InterestHandling == commons.InterestHandlingCapitalized {
+			upcoming[i].Amount += holidayInterest / float64(len(upcoming))
+		}
+	}
+	roundPayLoans(loan.Currency, upcoming)
+
+	// accrued interest charged as one more installment after the last one
+	accrued := []entity.PayLoanEntity{}
+	if data.InterestHandling == commons.InterestHandlingAccrued {
+		lastDue := upcoming[len(upcoming)-1].CreatedAt
+		accrued = newPaySchedule(loan.Id, holidayInterest, lastDue.Add(commons.DifferentTime), 1)
+	}
+
+	// shifted schedule and loan amount change together, a failure never leave holiday half applied
+	err = s.repo.Transaction.WithTransaction(ctx, func(repo *repository.Repository) error {
+		err := repo.PayLoan.Reschedule(ctx, upcoming)
+		if err != nil {
+			return err
+		}
+
+		if data.InterestHandling == commons.InterestHandlingWaived {
+			return nil
+		}
+
+		if len(accrued) > 0 {
+			err = repo.PayLoan.BatchInsert(ctx, accrued)
+			if err != nil {
+				return err
+			}
+		}
+
+		return repo.Loan.UpdateAmount(ctx, loan.Id, loan.Amount+holidayInterest)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(upcoming, accrued...), nil
+}
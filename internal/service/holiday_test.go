@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_GrantPaymentHoliday(t *testing.T) {
+	dueDate := time.Now().Add(commons.DifferentTime)
+	payLoans := func() []entity.PayLoanEntity {
+		return []entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 110, Status: commons.StatusPayLoanPayed, CreatedAt: dueDate.Add(-2 * commons.DifferentTime)},
+			{Id: 2, LoanId: 123, Amount: 110, Status: commons.StatusPayLoanUnpayed, CreatedAt: dueDate.Add(-1 * commons.DifferentTime)},
+			{Id: 4, LoanId: 123, Amount: 110, Status: commons.StatusPayLoanUnpayed, CreatedAt: dueDate.Add(commons.DifferentTime)},
+			{Id: 3, LoanId: 123, Amount: 110, Status: commons.StatusPayLoanUnpayed, CreatedAt: dueDate},
+		}
+	}
+
+	t.Run("success holiday with capitalized interest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+			Amount:      440,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return(payLoans(), nil)
+
+		payLoanRepoMock.EXPECT().Reschedule(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, rescheduled []entity.PayLoanEntity) error {
+			assert.Len(t, rescheduled, 2)
+			assert.Equal(t, 3, rescheduled[0].Id)
+			assert.Equal(t, dueDate.Add(2*commons.DifferentTime), rescheduled[0].CreatedAt)
+			assert.Equal(t, dueDate.Add(3*commons.DifferentTime), rescheduled[1].CreatedAt)
+			// 2 periods interest of 10 spread into 2 installments
+			assert.InDelta(t, float64(120), rescheduled[0].Amount, 0.001)
+			return nil
+		})
+
+		loaRepoMock.EXPECT().UpdateAmount(gomock.Any(), 123, gomock.Any()).DoAndReturn(func(ctx context.Context, loanId int, amount float64) error {
+			assert.InDelta(t, float64(460), amount, 0.001)
+			return nil
+		})
+
+		schedule, err := service.GrantPaymentHoliday(context.Background(), PaymentHolidayEntity{
+			Username:         "user123",
+			Periods:          2,
+			InterestHandling: commons.InterestHandlingCapitalized,
+		})
+
+		assert.Nil(t, err)
+		assert.Len(t, schedule, 2)
+	})
+
+	t.Run("success holiday with accrued interest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+			Amount:      440,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return(payLoans(), nil)
+
+		payLoanRepoMock.EXPECT().Reschedule(gomock.Any(), gomock.Any()).Return(nil)
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, accrued []entity.PayLoanEntity) error {
+			assert.Len(t, accrued, 1)
+			assert.InDelta(t, float64(10), accrued[0].Amount, 0.001)
+			assert.Equal(t, dueDate.Add(3*commons.DifferentTime), accrued[0].CreatedAt)
+			return nil
+		})
+
+		loaRepoMock.EXPECT().UpdateAmount(gomock.Any(), 123, gomock.Any()).Return(nil)
+
+		schedule, err := service.GrantPaymentHoliday(context.Background(), PaymentHolidayEntity{
+			Username:         "user123",
+			Periods:          1,
+			InterestHandling: commons.InterestHandlingAccrued,
+		})
+
+		assert.Nil(t, err)
+		assert.Len(t, schedule, 3)
+	})
+
+	t.Run("success holiday with waived interest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return(payLoans(), nil)
+
+		payLoanRepoMock.EXPECT().Reschedule(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, rescheduled []entity.PayLoanEntity) error {
+			assert.Equal(t, float64(110), rescheduled[0].Amount)
+			return nil
+		})
+
+		_, err := service.GrantPaymentHoliday(context.Background(), PaymentHolidayEntity{
+			Username:         "user123",
+			Periods:          1,
+			InterestHandling: commons.InterestHandlingWaived,
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error invalid interest handling", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.GrantPaymentHoliday(context.Background(), PaymentHolidayEntity{
+			Username:         "user123",
+			Periods:          1,
+			InterestHandling: 99,
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "invalid interest handling")
+	})
+}
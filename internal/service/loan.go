@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// LoanScheduleEntity is loan with every installment ordered by due date and summary of what is next to pay.
+// NextDueDate is zero when all installment settled
+type LoanScheduleEntity struct {
+	Loan          entity.LoanEntity
+	PayLoans      []entity.PayLoanEntity
+	Outstanding   OutstandingEntity
+	PaidCount     int
+	UnpaidCount   int
+	NextDueDate   time.Time
+	NextDueAmount float64
+}
+
+// GetLoan return loan by id regardless of its status
+func (s *Service) GetLoan(ctx context.Context, loanId int) (entity.LoanEntity, error) {
+	loan, err := s.repo.Loan.GetById(ctx, loanId)
+	if errors.Is(err, repository.ErrNotFound) {
+		return entity.LoanEntity{}, ErrLoanNotFound
+	}
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	return loan, nil
+}
+
+// GetLoanSchedule return loan by id with its full installment schedule
+func (s *Service) GetLoanSchedule(ctx context.Context, loanId int) (LoanScheduleEntity, error) {
+	loan, err := s.GetLoan(ctx, loanId)
+	if err != nil {
+		return LoanScheduleEntity{}, err
+	}
+
+	return s.loanSchedule(ctx, loan)
+}
+
+// GetBorrowerSchedule return schedule of active loan of user
+func (s *Service) GetBorrowerSchedule(ctx context.Context, username string) (LoanScheduleEntity, error) {
+	loan, err := s.getLoan(ctx, username, commons.StatusLoanNew)
+	if err != nil {
+		return LoanScheduleEntity{}, err
+	}
+
+	return s.loanSchedule(ctx, loan)
+}
+
+func (s *Service) loanSchedule(ctx context.Context, loan entity.LoanEntity) (LoanScheduleEntity, error) {
+	payLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+	if err != nil {
+		return LoanScheduleEntity{}, err
+	}
+
+	sort.Slice(payLoans, func(i, j int) bool {
+		return payLoans[i].CreatedAt.Before(payLoans[j].CreatedAt)
+	})
+
+	schedule := LoanScheduleEntity{
+		Loan:        loan,
+		PayLoans:    payLoans,
+		Outstanding: calculateOutstanding(loan, payLoans),
+	}
+
+	for _, payLoan := range payLoans {
+		switch payLoan.Status {
+		case commons.StatusPayLoanPayed:
+			schedule.PaidCount++
+		case commons.StatusPayLoanUnpayed:
+			schedule.UnpaidCount++
+		}
+	}
+
+	if next, ok := nextInstallment(payLoans); ok {
+		schedule.NextDueDate = next.CreatedAt
+		schedule.NextDueAmount = installmentDue(next)
+	}
+
+	return schedule, nil
+}
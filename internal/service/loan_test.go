@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_GetLoan(t *testing.T) {
+	t.Run("success get loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetById(gomock.Any(), 123).Return(entity.LoanEntity{
+			Id:       123,
+			Username: "user123",
+			Amount:   1100,
+		}, nil)
+
+		loan, err := service.GetLoan(context.Background(), 123)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "user123", loan.Username)
+	})
+
+	t.Run("error loan not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetById(gomock.Any(), 123).Return(entity.LoanEntity{}, repository.ErrNotFound)
+
+		_, err := service.GetLoan(context.Background(), 123)
+
+		assert.ErrorIs(t, err, ErrLoanNotFound)
+	})
+}
+
+func TestService_GetLoanSchedule(t *testing.T) {
+	t.Run("success get schedule with next due", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		firstDue := time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC)
+		paidAt := time.Date(2024, 8, 4, 10, 0, 0, 0, time.UTC)
+
+		loaRepoMock.EXPECT().GetById(gomock.Any(), 123).Return(entity.LoanEntity{
+			Id:     123,
+			Amount: 330,
+		}, nil)
+
+		// installment is returned not in order of due date
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 3, LoanId: 123, Amount: 110, FeeAmount: 5, Status: commons.StatusPayLoanUnpayed, CreatedAt: firstDue.AddDate(0, 0, 14)},
+			{Id: 1, LoanId: 123, Amount: 110, FeeAmount: 5, Status: commons.StatusPayLoanPayed, CreatedAt: firstDue, PaidAt: paidAt, PaidAmount: 115},
+			{Id: 2, LoanId: 123, Amount: 110, FeeAmount: 5, Status: commons.StatusPayLoanUnpayed, CreatedAt: firstDue.AddDate(0, 0, 7)},
+		}, nil)
+
+		schedule, err := service.GetLoanSchedule(context.Background(), 123)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 123, schedule.Loan.Id)
+		assert.Equal(t, []int{1, 2, 3}, []int{schedule.PayLoans[0].Id, schedule.PayLoans[1].Id, schedule.PayLoans[2].Id})
+		assert.Equal(t, paidAt, schedule.PayLoans[0].PaidAt)
+		assert.Equal(t, 1, schedule.PaidCount)
+		assert.Equal(t, 2, schedule.UnpaidCount)
+		assert.Equal(t, firstDue.AddDate(0, 0, 7), schedule.NextDueDate)
+		assert.Equal(t, float64(115), schedule.NextDueAmount)
+		assert.Equal(t, float64(230), schedule.Outstanding.Total)
+	})
+
+	t.Run("success fully settled loan has no next due", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetById(gomock.Any(), 123).Return(entity.LoanEntity{Id: 123, Amount: 110}, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 110, Status: commons.StatusPayLoanPayed},
+		}, nil)
+
+		schedule, err := service.GetLoanSchedule(context.Background(), 123)
+
+		assert.Nil(t, err)
+		assert.True(t, schedule.NextDueDate.IsZero())
+		assert.Equal(t, float64(0), schedule.NextDueAmount)
+	})
+
+	t.Run("error loan not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetById(gomock.Any(), 123).Return(entity.LoanEntity{}, repository.ErrNotFound)
+
+		_, err := service.GetLoanSchedule(context.Background(), 123)
+
+		assert.ErrorIs(t, err, ErrLoanNotFound)
+	})
+}
+
+func TestService_GetBorrowerSchedule(t *testing.T) {
+	t.Run("success get schedule of active loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{Id: 123, Amount: 110}, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 110, Status: commons.StatusPayLoanUnpayed},
+		}, nil)
+
+		schedule, err := service.GetBorrowerSchedule(context.Background(), "user123")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 123, schedule.Loan.Id)
+		assert.Len(t, schedule.PayLoans, 1)
+	})
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// getPromo return promo when eligible for the loan, user can be empty when borrower not known yet
+func (s *Service) getPromo(ctx context.Context, code string, product entity.LoanProductEntity, principal float64, user entity.UserEntity) (entity.PromoEntity, error) {
+	if code == "" {
+		return entity.PromoEntity{}, nil
+	}
+
+	promo, err := s.repo.Promo.Get(ctx, code)
+	if err != nil {
+		return entity.PromoEntity{}, err
+	}
+
+	if promo.Code == "" {
+		return entity.PromoEntity{}, ErrPromoNotFound
+	}
+
+	now := s.clock.Now()
+	if (!promo.ValidFrom.IsZero() && now.Before(promo.ValidFrom)) || (!promo.ValidTo.IsZero() && now.After(promo.ValidTo)) {
+		return entity.PromoEntity{}, ErrPromoNotActive
+	}
+
+	if promo.UsageLimit > 0 && promo.UsageCount >= promo.UsageLimit {
+		return entity.PromoEntity{}, ErrPromoUsageLimitReached
+	}
+
+	if promo.ProductCode != "" && promo.ProductCode != product.Code {
+		return entity.PromoEntity{}, ErrPromoProductNotEligible
+	}
+
+	if (promo.MinAmount > 0 && principal < promo.MinAmount) || (promo.MaxAmount > 0 && principal > promo.MaxAmount) {
+		return entity.PromoEntity{}, ErrPromoAmountNotEligible
+	}
+
+	if promo.NewBorrowerOnly {
+		if user.Username == "" {
+			return entity.PromoEntity{}, ErrPromoRequireBorrower
+		}
+
+		if user.Status != commons.StatusUserNew {
+			return entity.PromoEntity{}, ErrPromoNewBorrowerOnly
+		}
+	}
+
+	return promo, nil
+}
+
+// applyPromo discount interest of regular installments based on promo, it return total discount
+func applyPromo(promo entity.PromoEntity, product entity.LoanProductEntity, payLoans []entity.PayLoanEntity) float64 {
+	total := float64(0)
+	for i := 0; i < product.Tenor && i < len(payLoans); i++ {
+		interest := interestPortion(payLoans[i].Amount, product.Interest)
+
+		discount := float64(0)
+		switch promo.DiscountType {
+		case commons.PromoDiscountPercentage:
+			discount = interest * promo.DiscountValue / 100
+		case commons.PromoDiscountInterestFree:
+			if i < int(promo.DiscountValue) {
+				discount = interest
+			}
+		}
+
+		payLoans[i].Amount -= discount
+		payLoans[i].DiscountAmount = discount
+		total += discount
+	}
+
+	return total
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CreateLoan_Promo(t *testing.T) {
+	t.Run("success create loan with interest free promo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Promo:       promoRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		promoRepoMock.EXPECT().Get(gomock.Any(), "FREE2").Return(entity.PromoEntity{
+			Code:            "FREE2",
+			DiscountType:    commons.PromoDiscountInterestFree,
+			DiscountValue:   2,
+			ValidFrom:       time.Now().AddDate(0, 0, -1),
+			ValidTo:         time.Now().AddDate(0, 0, 1),
+			UsageLimit:      10,
+			UsageCount:      9,
+			NewBorrowerOnly: true,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		promoRepoMock.EXPECT().Use(gomock.Any(), "FREE2").Return(true, nil)
+
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			// interest of 11 waived on first 2 installments
+			assert.Equal(t, "FREE2", loan.PromoCode)
+			assert.InDelta(t, float64(22), loan.DiscountAmount, 0.001)
+			assert.InDelta(t, float64(1078), loan.Amount, 0.001)
+			loan.Id = 1
+			return loan, nil
+		})
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.InDelta(t, float64(110), payLoans[0].Amount, 0.001)
+			assert.InDelta(t, float64(11), payLoans[1].DiscountAmount, 0.001)
+			assert.InDelta(t, float64(121), payLoans[2].Amount, 0.001)
+			assert.Equal(t, float64(0), payLoans[2].DiscountAmount)
+			return nil
+		})
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+
+		err := service.CreateLoan(context.Background(), CreateLoanEntity{
+			Username:  "user123",
+			Amount:    1000,
+			PromoCode: "FREE2",
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error usage limit reached while booking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Promo:       promoRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserClosedLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		promoRepoMock.EXPECT().Get(gomock.Any(), "HALF").Return(entity.PromoEntity{
+			Code:          "HALF",
+			DiscountType:  commons.PromoDiscountPercentage,
+			DiscountValue: 50,
+			UsageLimit:    10,
+			UsageCount:    9,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		// other loan take the last usage after promo validated
+		promoRepoMock.EXPECT().Use(gomock.Any(), "HALF").Return(false, nil)
+
+		err := service.CreateLoan(context.Background(), CreateLoanEntity{
+			Username:  "user123",
+			Amount:    1000,
+			PromoCode: "HALF",
+		})
+
+		assert.EqualError(t, err, "promo code usage limit reached")
+	})
+}
+
+func TestService_getPromo(t *testing.T) {
+	product := entity.LoanProductEntity{
+		Code: commons.DefaultProductCode,
+	}
+	newUser := entity.UserEntity{
+		Username: "user123",
+		Status:   commons.StatusUserNew,
+	}
+
+	tests := []struct {
+		name  string
+		promo entity.PromoEntity
+		user  entity.UserEntity
+		err   string
+	}{
+		{
+			name:  "not found",
+			promo: entity.PromoEntity{},
+			user:  newUser,
+			err:   "promo code not found",
+		},
+		{
+			name:  "expired",
+			promo: entity.PromoEntity{Code: "PROMO", ValidTo: time.Now().AddDate(0, 0, -1)},
+			user:  newUser,
+			err:   "promo code not valid at this time",
+		},
+		{
+			name:  "not started",
+			promo: entity.PromoEntity{Code: "PROMO", ValidFrom: time.Now().AddDate(0, 0, 1)},
+			user:  newUser,
+			err:   "promo code not valid at this time",
+		},
+		{
+			name:  "usage limit reached",
+			promo: entity.PromoEntity{Code: "PROMO", UsageLimit: 5, UsageCount: 5},
+			user:  newUser,
+			err:   "promo code usage limit reached",
+		},
+		{
+			name:  "other product",
+			promo: entity.PromoEntity{Code: "PROMO", ProductCode: "other"},
+			user:  newUser,
+			err:   "promo code not eligible for loan product",
+		},
+		{
+			name:  "amount below minimum",
+			promo: entity.PromoEntity{Code: "PROMO", MinAmount: 5000},
+			user:  newUser,
+			err:   "promo code not eligible for loan amount",
+		},
+		{
+			name:  "amount above maximum",
+			promo: entity.PromoEntity{Code: "PROMO", MaxAmount: 500},
+			user:  newUser,
+			err:   "promo code not eligible for loan amount",
+		},
+		{
+			name:  "returning borrower",
+			promo: entity.PromoEntity{Code: "PROMO", NewBorrowerOnly: true},
+			user:  entity.UserEntity{Username: "user123", Status: commons.StatusUserClosedLoan},
+			err:   "promo code only for new borrower",
+		},
+		{
+			name:  "unknown borrower",
+			promo: entity.PromoEntity{Code: "PROMO", NewBorrowerOnly: true},
+			user:  entity.UserEntity{},
+			err:   "promo code require borrower",
+		},
+		{
+			name:  "eligible",
+			promo: entity.PromoEntity{Code: "PROMO", ProductCode: commons.DefaultProductCode, MinAmount: 500, MaxAmount: 5000, NewBorrowerOnly: true},
+			user:  newUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+
+			service := &Service{
+				clock: clock.System,
+				repo: &repository.Repository{
+					Promo: promoRepoMock,
+				},
+			}
+
+			promoRepoMock.EXPECT().Get(gomock.Any(), "PROMO").Return(tt.promo, nil)
+
+			promo, err := service.getPromo(context.Background(), "PROMO", product, 1000, tt.user)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "PROMO", promo.Code)
+		})
+	}
+
+	t.Run("error get promo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+
+		service := &Service{
+			clock: clock.System,
+			repo: &repository.Repository{
+				Promo: promoRepoMock,
+			},
+		}
+
+		promoRepoMock.EXPECT().Get(gomock.Any(), "PROMO").Return(entity.PromoEntity{}, errors.New("error"))
+
+		_, err := service.getPromo(context.Background(), "PROMO", product, 1000, newUser)
+
+		assert.EqualError(t, err, "error")
+	})
+}
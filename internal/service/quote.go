@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type QuoteLoanEntity struct {
+	Username    string
+	Amount      float64
+	ProductCode string
+	PromoCode   string
+}
+
+type LoanQuoteEntity struct {
+	Loan     entity.LoanEntity
+	PayLoans []entity.PayLoanEntity
+}
+
+// QuoteLoan calculate loan and its schedule the same way as create loan without saving it,
+// username is optional and only used to check promo eligibility
+func (s *Service) QuoteLoan(ctx context.Context, data QuoteLoanEntity) (LoanQuoteEntity, error) {
+	user := entity.UserEntity{}
+	if data.Username != "" {
+		var err error
+		user, err = s.repo.User.GetUser(ctx, data.Username)
+		if err != nil {
+			return LoanQuoteEntity{}, err
+		}
+	}
+
+	product, err := s.getLoanProduct(ctx, data.ProductCode)
+	if err != nil {
+		return LoanQuoteEntity{}, err
+	}
+
+	promo, err := s.getPromo(ctx, data.PromoCode, product, data.Amount, user)
+	if err != nil {
+		return LoanQuoteEntity{}, err
+	}
+
+	draft, err := s.draftLoan(ctx, data.Username, data.Amount, product, promo)
+	if err != nil {
+		return LoanQuoteEntity{}, err
+	}
+
+	return LoanQuoteEntity{
+		Loan:     draft.Loan,
+		PayLoans: draft.PayLoans,
+	}, nil
+}
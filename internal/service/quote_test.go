@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_QuoteLoan(t *testing.T) {
+	t.Run("success quote loan with percentage promo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Promo:       promoRepoMock,
+		}, clock.System)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		promoRepoMock.EXPECT().Get(gomock.Any(), "HALF").Return(entity.PromoEntity{
+			Code:          "HALF",
+			DiscountType:  commons.PromoDiscountPercentage,
+			DiscountValue: 50,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		quote, err := service.QuoteLoan(context.Background(), QuoteLoanEntity{
+			Amount:    1000,
+			PromoCode: "HALF",
+		})
+
+		assert.Nil(t, err)
+		assert.InDelta(t, float64(55), quote.Loan.DiscountAmount, 0.001)
+		assert.InDelta(t, float64(1045), quote.Loan.Amount, 0.001)
+		assert.Len(t, quote.PayLoans, 10)
+		assert.InDelta(t, float64(115.5), quote.PayLoans[0].Amount, 0.001)
+		assert.InDelta(t, float64(5.5), quote.PayLoans[0].DiscountAmount, 0.001)
+	})
+
+	t.Run("error promo require borrower", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		promoRepoMock := mock_repositories.NewMockIPromoRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			LoanProduct: loanProductRepoMock,
+			Promo:       promoRepoMock,
+		}, clock.System)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		promoRepoMock.EXPECT().Get(gomock.Any(), "NEW").Return(entity.PromoEntity{
+			Code:            "NEW",
+			DiscountType:    commons.PromoDiscountInterestFree,
+			DiscountValue:   4,
+			NewBorrowerOnly: true,
+		}, nil)
+
+		_, err := service.QuoteLoan(context.Background(), QuoteLoanEntity{
+			Amount:    1000,
+			PromoCode: "NEW",
+		})
+
+		assert.EqualError(t, err, "promo code require borrower")
+	})
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type RefinanceLoanEntity struct {
+	Username    string
+	Amount      float64
+	ProductCode string
+}
+
+type RefinanceLoanResult struct {
+	Loan            entity.LoanEntity
+	PayoffAmount    float64
+	DisbursedAmount float64
+}
+
+// RefinanceLoan top up active loan of user, outstanding of the old loan is paid off from the new loan
+// and the rest is disbursed to user. Old loan is closed and linked to the new loan in one transaction
+func (s *Service) RefinanceLoan(ctx context.Context, data RefinanceLoanEntity) (RefinanceLoanResult, error) {
+	user, err := s.repo.User.GetUser(ctx, data.Username)
+	if err != nil {
+		return RefinanceLoanResult{}, err
+	}
+
+	if user.Username == "" {
+		return RefinanceLoanResult{}, ErrUserNotRegistered
+	}
+
+	if user.KycStatus != commons.StatusKycVerified {
+		return RefinanceLoanResult{}, ErrKycNotVerified
+	}
+
+	if user.Status != commons.StatusUserActiveLoan {
+		return RefinanceLoanResult{}, ErrUserNotActiveLoan
+	}
+
+	oldLoan, err := s.getLoan(ctx, data.Username, commons.StatusLoanNew)
+	if err != nil {
+		return RefinanceLoanResult{}, err
+	}
+
+	payLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, oldLoan.Id)
+	if err != nil {
+		return RefinanceLoanResult{}, err
+	}
+
+	outstanding := calculateOutstanding(oldLoan, payLoans).Total
+
+	// use product of the old loan when product not specified
+	productCode := data.ProductCode
+	if productCode == "" {
+		productCode = oldLoan.ProductCode
+	}
+
+	product, err := s.getLoanProduct(ctx, productCode)
+	if err != nil {
+		return RefinanceLoanResult{}, err
+	}
+
+	// outstanding of old loan is paid off from disbursement, both must be in the same currency
+	if product.Currency != oldLoan.Currency {
+		return RefinanceLoanResult{}, errProductCurrencyMismatch(oldLoan.Currency)
+	}
+
+	draft, err := s.draftLoan(ctx, data.Username, data.Amount, product, entity.PromoEntity{})
+	if err != nil {
+		return RefinanceLoanResult{}, err
+	}
+	draft.Loan.RefinancedFrom = oldLoan.Id
+
+	// outstanding is paid off from disbursement of new loan
+	if draft.Loan.DisbursedAmount <= outstanding {
+		return RefinanceLoanResult{}, ErrAmountBelowOutstanding
+	}
+
+	oldLoan.Status = commons.StatusLoanClosed
+	oldLoan.ClosedAt = s.clock.Now()
+	oldLoan.ClosedReason = "refinanced"
+
+	// old loan is closed first and only while still open, so concurrent refinance of the same loan
+	// return ErrLoanNotOpen and never book second loan
+	var newLoan entity.LoanEntity
+	err = s.repo.Transaction.WithTransaction(ctx, func(repo *repository.Repository) error {
+		closed, err := repo.Loan.Close(ctx, oldLoan.Id, oldLoan)
+		if err != nil {
+			return err
+		}
+
+		if !closed {
+			return ErrLoanNotOpen
+		}
+
+		err = repo.PayLoan.UpdateStatusByLoanId(ctx, oldLoan.Id, commons.StatusPayLoanUnpayed, commons.StatusPayLoanRefinanced)
+		if err != nil {
+			return err
+		}
+
+		newLoan, err = s.bookLoan(ctx, repo, draft)
+		return err
+	})
+	if err != nil {
+		return RefinanceLoanResult{}, err
+	}
+
+	return RefinanceLoanResult{
+		Loan:            newLoan,
+		PayoffAmount:    outstanding,
+		DisbursedAmount: newLoan.DisbursedAmount - outstanding,
+	}, nil
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RefinanceLoan(t *testing.T) {
+	t.Run("success refinance loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserActiveLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			Amount:      1100,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 100, Status: commons.StatusPayLoanPayed},
+			{Id: 2, LoanId: 123, Amount: 100, Status: commons.StatusPayLoanPayed},
+			{Id: 3, LoanId: 123, Amount: 100, Status: commons.StatusPayLoanUnpayed},
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).DoAndReturn(func(ctx context.Context, loanId int, loan entity.LoanEntity) (bool, error) {
+			assert.Equal(t, commons.StatusLoanClosed, loan.Status)
+			assert.Equal(t, "refinanced", loan.ClosedReason)
+			return true, nil
+		})
+
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanRefinanced).Return(nil)
+
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			assert.Equal(t, 123, loan.RefinancedFrom)
+			assert.Equal(t, float64(2200), loan.Amount)
+			loan.Id = 124
+			return loan, nil
+		})
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 10)
+			assert.Equal(t, 124, payLoans[0].LoanId)
+			return nil
+		})
+
+		result, err := service.RefinanceLoan(context.Background(), RefinanceLoanEntity{
+			Username: "user123",
+			Amount:   2000,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 124, result.Loan.Id)
+		assert.Equal(t, float64(900), result.PayoffAmount)
+		assert.Equal(t, float64(1100), result.DisbursedAmount)
+	})
+
+	t.Run("error amount not greater than outstanding", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserActiveLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+			Amount:      1100,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+
+		// disbursement after origination fee not enough to pay off outstanding
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			Interest:       10,
+			Tenor:          10,
+			OriginationFee: 10,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		_, err := service.RefinanceLoan(context.Background(), RefinanceLoanEntity{
+			Username: "user123",
+			Amount:   1200,
+		})
+
+		assert.EqualError(t, err, "amount must be greater than outstanding")
+	})
+
+	t.Run("error product currency not same with loan currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserActiveLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+			Currency:    "IDR",
+			Amount:      1100000,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "USD_LOAN").Return(entity.LoanProductEntity{
+			Code:     "USD_LOAN",
+			Currency: "USD",
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		_, err := service.RefinanceLoan(context.Background(), RefinanceLoanEntity{
+			Username:    "user123",
+			Amount:      5000,
+			ProductCode: "USD_LOAN",
+		})
+
+		assert.EqualError(t, err, "product currency not same with loan currency : IDR")
+	})
+
+	t.Run("error user not active loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		_, err := service.RefinanceLoan(context.Background(), RefinanceLoanEntity{
+			Username: "user123",
+			Amount:   1000,
+		})
+
+		assert.EqualError(t, err, "user not active loan")
+	})
+
+	t.Run("error rollback when create new loan failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserActiveLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: commons.DefaultProductCode,
+			Amount:      1100,
+			CreatedAt:   time.Now(),
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(true, nil)
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanRefinanced).Return(nil)
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).Return(entity.LoanEntity{}, errors.New("error"))
+
+		_, err := service.RefinanceLoan(context.Background(), RefinanceLoanEntity{
+			Username: "user123",
+			Amount:   2000,
+		})
+
+		assert.EqualError(t, err, "error")
+	})
+
+	t.Run("error old loan already refinanced by concurrent request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		// both request read the same open loan before either one commit
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserActiveLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil).Times(2)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+			Amount:      1100,
+		}, nil).Times(2)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 100, Status: commons.StatusPayLoanUnpayed},
+		}, nil).Times(2)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    10,
+		}, nil).Times(2)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil).Times(2)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		}).Times(2)
+
+		// first request close the old loan and book new loan, second find the old loan no longer open
+		gomock.InOrder(
+			loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(true, nil),
+			loaRepoMock.EXPECT().Close(gomock.Any(), 123, gomock.Any()).Return(false, nil),
+		)
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanRefinanced).Return(nil).Times(1)
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			loan.Id = 124
+			return loan, nil
+		}).Times(1)
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		data := RefinanceLoanEntity{
+			Username: "user123",
+			Amount:   2000,
+		}
+
+		first, err := service.RefinanceLoan(context.Background(), data)
+		assert.Nil(t, err)
+		assert.Equal(t, 124, first.Loan.Id)
+
+		_, err = service.RefinanceLoan(context.Background(), data)
+		assert.ErrorIs(t, err, ErrLoanNotOpen)
+	})
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/reporting"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// source of portfolio report
+const (
+	ReportSourceLive     = "live"
+	ReportSourceSnapshot = "snapshot"
+)
+
+type PortfolioReportEntity struct {
+	Date    time.Time
+	Source  string
+	Metrics []reporting.Metrics
+}
+
+// GetPortfolioReport report portfolio as of end of date, snapshot is used when the date already snapshotted
+func (s *Service) GetPortfolioReport(ctx context.Context, date time.Time) (PortfolioReportEntity, error) {
+	date = truncateDate(date)
+
+	snapshots, err := s.repo.ReportSnapshot.GetByDate(ctx, date)
+	if err != nil {
+		return PortfolioReportEntity{}, err
+	}
+
+	if len(snapshots) > 0 {
+		metrics := []reporting.Metrics{}
+		for _, snapshot := range snapshots {
+			metrics = append(metrics, convertSnapshotToMetrics(snapshot))
+		}
+
+		return PortfolioReportEntity{
+			Date:    date,
+			Source:  ReportSourceSnapshot,
+			Metrics: metrics,
+		}, nil
+	}
+
+	metrics, err := s.computePortfolioReport(ctx, date)
+	if err != nil {
+		return PortfolioReportEntity{}, err
+	}
+
+	return PortfolioReportEntity{
+		Date:    date,
+		Source:  ReportSourceLive,
+		Metrics: metrics,
+	}, nil
+}
+
+// SnapshotPortfolioReport compute report of date and save it, run again for same date replace the snapshot
+func (s *Service) SnapshotPortfolioReport(ctx context.Context, date time.Time) error {
+	date = truncateDate(date)
+
+	metrics, err := s.computePortfolioReport(ctx, date)
+	if err != nil {
+		return err
+	}
+
+	snapshots := []entity.ReportSnapshotEntity{}
+	for _, m := range metrics {
+		snapshots = append(snapshots, entity.ReportSnapshotEntity{
+			ReportDate:          date,
+			Currency:            m.Currency,
+			LoanCount:           m.LoanCount,
+			Outstanding:         m.Outstanding,
+			Par30Amount:         m.Par30Amount,
+			Par90Amount:         m.Par90Amount,
+			Par30:               m.Par30,
+			Par90:               m.Par90,
+			DelinquentBorrowers: m.DelinquentBorrowers,
+			CollectionCount:     m.CollectionCount,
+			Collections:         m.Collections,
+			CreatedAt:           s.clock.Now(),
+		})
+	}
+
+	return s.repo.ReportSnapshot.Save(ctx, snapshots)
+}
+
+func (s *Service) computePortfolioReport(ctx context.Context, date time.Time) ([]reporting.Metrics, error) {
+	asOf := date.AddDate(0, 0, 1)
+
+	exposureEntities, err := s.repo.PayLoan.GetExposures(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.rollExposures(ctx, exposureEntities, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionEntities, err := s.repo.PayLoan.SumCollections(ctx, date, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	exposures := []reporting.Exposure{}
+	for _, exposure := range exposureEntities {
+		exposures = append(exposures, convertExposure(exposure))
+	}
+
+	collections := []reporting.Collection{}
+	for _, collection := range collectionEntities {
+		collections = append(collections, reporting.Collection{
+			Currency: collection.Currency,
+			Count:    collection.Count,
+			Amount:   collection.Amount,
+		})
+	}
+
+	metrics := reporting.Compute(asOf, exposures, collections)
+	for i := range metrics {
+		metrics[i].Outstanding = currency.Round(metrics[i].Currency, metrics[i].Outstanding)
+		metrics[i].Par30Amount = currency.Round(metrics[i].Currency, metrics[i].Par30Amount)
+		metrics[i].Par90Amount = currency.Round(metrics[i].Currency, metrics[i].Par90Amount)
+		metrics[i].Collections = currency.Round(metrics[i].Currency, metrics[i].Collections)
+	}
+
+	return metrics, nil
+}
+
+// rollExposures recount overdue installment of loan whose product roll due date to business day,
+// so report flag installment late by the same rolled due date as schedule task
+func (s *Service) rollExposures(ctx context.Context, exposures []entity.ExposureEntity, asOf time.Time) error {
+	roller := s.newDueDateRoller()
+
+	rolled := map[int]*entity.ExposureEntity{}
+	loanIds := []int{}
+	for i := range exposures {
+		product, err := roller.product(ctx, exposures[i].ProductCode)
+		if err != nil {
+			return err
+		}
+
+		if product.RollConvention != commons.RollConventionNone {
+			rolled[exposures[i].LoanId] = &exposures[i]
+			loanIds = append(loanIds, exposures[i].LoanId)
+		}
+	}
+
+	for start := 0; start < len(loanIds); start += scheduleTaskPageSize {
+		page := loanIds[start:min(start+scheduleTaskPageSize, len(loanIds))]
+
+		payLoans, err := s.repo.PayLoan.GetUnpaidAtByLoanIds(ctx, page, asOf)
+		if err != nil {
+			return err
+		}
+
+		for _, loanId := range page {
+			rolled[loanId].OverdueCount = 0
+			rolled[loanId].OldestDueDate = time.Time{}
+		}
+
+		for _, payLoan := range payLoans {
+			exposure := rolled[payLoan.LoanId]
+
+			dueDate, err := roller.roll(ctx, exposure.ProductCode, payLoan.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			if dueDate.Before(asOf) {
+				exposure.OverdueCount++
+			}
+			if exposure.OldestDueDate.IsZero() || dueDate.Before(exposure.OldestDueDate) {
+				exposure.OldestDueDate = dueDate
+			}
+		}
+	}
+
+	return nil
+}
+
+func convertExposure(exposure entity.ExposureEntity) reporting.Exposure {
+	return reporting.Exposure{
+		LoanId:        exposure.LoanId,
+		Username:      exposure.Username,
+		Currency:      exposure.Currency,
+		Outstanding:   exposure.Outstanding,
+		OverdueCount:  exposure.OverdueCount,
+		OldestDueDate: exposure.OldestDueDate,
+	}
+}
+
+func convertSnapshotToMetrics(snapshot entity.ReportSnapshotEntity) reporting.Metrics {
+	return reporting.Metrics{
+		Currency:            snapshot.Currency,
+		LoanCount:           snapshot.LoanCount,
+		Outstanding:         snapshot.Outstanding,
+		Par30Amount:         snapshot.Par30Amount,
+		Par90Amount:         snapshot.Par90Amount,
+		Par30:               snapshot.Par30,
+		Par90:               snapshot.Par90,
+		DelinquentBorrowers: snapshot.DelinquentBorrowers,
+		CollectionCount:     snapshot.CollectionCount,
+		Collections:         snapshot.Collections,
+	}
+}
+
+func truncateDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_GetPortfolioReport(t *testing.T) {
+	date := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	reportDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success live report", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		reportSnapshotRepoMock := mock_repositories.NewMockIReportSnapshotRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan:        payLoanRepoMock,
+			LoanProduct:    loanProductRepoMock,
+			ReportSnapshot: reportSnapshotRepoMock,
+		}, clock.System)
+
+		reportSnapshotRepoMock.EXPECT().GetByDate(gomock.Any(), reportDate).Return([]entity.ReportSnapshotEntity{}, nil)
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{Code: commons.DefaultProductCode}, nil)
+		payLoanRepoMock.EXPECT().GetExposures(gomock.Any(), asOf).Return([]entity.ExposureEntity{
+			{LoanId: 1, Username: "bambang", Currency: "IDR", Outstanding: 3000000, OverdueCount: 3, OldestDueDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{LoanId: 2, Username: "joko", Currency: "IDR", Outstanding: 1000000, OverdueCount: 1, OldestDueDate: time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		payLoanRepoMock.EXPECT().SumCollections(gomock.Any(), reportDate, asOf).Return([]entity.CollectionEntity{
+			{Currency: "IDR", Count: 2, Amount: 550000},
+		}, nil)
+
+		report, err := service.GetPortfolioReport(context.Background(), date)
+
+		assert.Nil(t, err)
+		assert.Equal(t, ReportSourceLive, report.Source)
+		assert.Equal(t, reportDate, report.Date)
+		assert.Len(t, report.Metrics, 1)
+		assert.Equal(t, 2, report.Metrics[0].LoanCount)
+		assert.Equal(t, float64(4000000), report.Metrics[0].Outstanding)
+		assert.Equal(t, float64(3000000), report.Metrics[0].Par30Amount)
+		assert.Equal(t, 0.75, report.Metrics[0].Par30)
+		assert.Equal(t, float64(0), report.Metrics[0].Par90Amount)
+		assert.Equal(t, 1, report.Metrics[0].DelinquentBorrowers)
+		assert.Equal(t, float64(550000), report.Metrics[0].Collections)
+	})
+
+	t.Run("success live report count late installment by rolled due date", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		holidayRepoMock := mock_repositories.NewMockIHolidayRepository(ctrl)
+		reportSnapshotRepoMock := mock_repositories.NewMockIReportSnapshotRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan:        payLoanRepoMock,
+			LoanProduct:    loanProductRepoMock,
+			Holiday:        holidayRepoMock,
+			ReportSnapshot: reportSnapshotRepoMock,
+		}, clock.System)
+
+		reportSnapshotRepoMock.EXPECT().GetByDate(gomock.Any(), reportDate).Return([]entity.ReportSnapshotEntity{}, nil)
+
+		// installment due on friday 1 march is holiday and rolled to monday, so only one installment late
+		payLoanRepoMock.EXPECT().GetExposures(gomock.Any(), asOf).Return([]entity.ExposureEntity{
+			{LoanId: 3, Username: "agus", Currency: "IDR", ProductCode: "ROLLED", Outstanding: 1000000, OverdueCount: 2, OldestDueDate: time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "ROLLED").Return(entity.LoanProductEntity{
+			Code:           "ROLLED",
+			Country:        "ID",
+			RollConvention: commons.RollConventionFollowing,
+		}, nil)
+		holidayRepoMock.EXPECT().GetByCountry(gomock.Any(), "ID").Return([]entity.HolidayEntity{
+			{Country: "ID", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		payLoanRepoMock.EXPECT().GetUnpaidAtByLoanIds(gomock.Any(), []int{3}, asOf).Return([]entity.PayLoanEntity{
+			{LoanId: 3, CreatedAt: time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC)},
+			{LoanId: 3, CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		payLoanRepoMock.EXPECT().SumCollections(gomock.Any(), reportDate, asOf).Return([]entity.CollectionEntity{}, nil)
+
+		report, err := service.GetPortfolioReport(context.Background(), date)
+
+		assert.Nil(t, err)
+		assert.Len(t, report.Metrics, 1)
+		assert.Equal(t, 0, report.Metrics[0].DelinquentBorrowers)
+	})
+
+	t.Run("success snapshot report", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		reportSnapshotRepoMock := mock_repositories.NewMockIReportSnapshotRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			ReportSnapshot: reportSnapshotRepoMock,
+		}, clock.System)
+
+		reportSnapshotRepoMock.EXPECT().GetByDate(gomock.Any(), reportDate).Return([]entity.ReportSnapshotEntity{
+			{ReportDate: reportDate, Currency: "IDR", LoanCount: 2, Outstanding: 4000000, Par30: 0.75},
+		}, nil)
+
+		report, err := service.GetPortfolioReport(context.Background(), date)
+
+		assert.Nil(t, err)
+		assert.Equal(t, ReportSourceSnapshot, report.Source)
+		assert.Equal(t, 0.75, report.Metrics[0].Par30)
+	})
+
+	t.Run("error get exposures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		reportSnapshotRepoMock := mock_repositories.NewMockIReportSnapshotRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan:        payLoanRepoMock,
+			ReportSnapshot: reportSnapshotRepoMock,
+		}, clock.System)
+
+		reportSnapshotRepoMock.EXPECT().GetByDate(gomock.Any(), reportDate).Return([]entity.ReportSnapshotEntity{}, nil)
+		payLoanRepoMock.EXPECT().GetExposures(gomock.Any(), asOf).Return(nil, errors.New("error"))
+
+		_, err := service.GetPortfolioReport(context.Background(), date)
+
+		assert.EqualError(t, err, "error")
+	})
+}
+
+func TestService_SnapshotPortfolioReport(t *testing.T) {
+	reportDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success snapshot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		reportSnapshotRepoMock := mock_repositories.NewMockIReportSnapshotRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan:        payLoanRepoMock,
+			LoanProduct:    loanProductRepoMock,
+			ReportSnapshot: reportSnapshotRepoMock,
+		}, clock.System)
+
+		payLoanRepoMock.EXPECT().GetExposures(gomock.Any(), asOf).Return([]entity.ExposureEntity{
+			{LoanId: 1, Username: "bambang", Currency: "USD", Outstanding: 110.5},
+		}, nil)
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{Code: commons.DefaultProductCode}, nil)
+		payLoanRepoMock.EXPECT().SumCollections(gomock.Any(), reportDate, asOf).Return([]entity.CollectionEntity{}, nil)
+		reportSnapshotRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, datas []entity.ReportSnapshotEntity) error {
+			assert.Len(t, datas, 1)
+			assert.Equal(t, reportDate, datas[0].ReportDate)
+			assert.Equal(t, "USD", datas[0].Currency)
+			assert.Equal(t, 110.5, datas[0].Outstanding)
+			return nil
+		})
+
+		err := service.SnapshotPortfolioReport(context.Background(), reportDate)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error sum collections", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		payLoanRepoMock.EXPECT().GetExposures(gomock.Any(), asOf).Return([]entity.ExposureEntity{}, nil)
+		payLoanRepoMock.EXPECT().SumCollections(gomock.Any(), reportDate, asOf).Return(nil, errors.New("error"))
+
+		err := service.SnapshotPortfolioReport(context.Background(), reportDate)
+
+		assert.EqualError(t, err, "error")
+	})
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type RestructureLoanEntity struct {
+	Username string
+	// number of installments for the new schedule
+	Tenor int
+	// number of periods without installment before new schedule start
+	HolidayPeriods int
+	// when true overdue installments are spread into new schedule,
+	// otherwise overdue installments are merged into one installment due with the first installment of new schedule
+	CapitalizeArrears bool
+}
+
+func (s *Service) RestructureLoan(ctx context.Context, data RestructureLoanEntity) (entity.LoanRestructureEntity, error) {
+	if data.Tenor <= 0 {
+		return entity.LoanRestructureEntity{}, ErrInvalidTenor
+	}
+
+	if data.HolidayPeriods < 0 {
+		return entity.LoanRestructureEntity{}, ErrInvalidHolidayPeriods
+	}
+
+	user, err := s.repo.User.GetUser(ctx, data.Username)
+	if err != nil {
+		return entity.LoanRestructureEntity{}, err
+	}
+
+	if user.Status != commons.StatusUserActiveLoan && user.Status != commons.StatusUserDeliquent {
+		return entity.LoanRestructureEntity{}, ErrUserNotOnOpenLoan
+	}
+
+	loan, err := s.getLoan(ctx, data.Username, commons.StatusLoanNew)
+	if err != nil {
+		return entity.LoanRestructureEntity{}, err
+	}
+
+	product, err := s.getLoanProduct(ctx, loan.ProductCode)
+	if err != nil {
+		return entity.LoanRestructureEntity{}, err
+	}
+
+	payLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+	if err != nil {
+		return entity.LoanRestructureEntity{}, err
+	}
+
+	// remaining balance is every unpaid installment, arrears is the part already due
+	now := s.clock.Now()
+	outstanding := float64(0)
+	arrears := float64(0)
+	fee := float64(0)
+	feeTax := float64(0)
+	withholdingTax := float64(0)
+	for _, payLoan := range payLoans {
+		if payLoan.Status != commons.StatusPayLoanUnpayed {
+			continue
+		}
+
+		outstanding += payLoan.Amount
+		fee += payLoan.FeeAmount
+		feeTax += payLoan.TaxAmount
+		withholdingTax += payLoan.WithholdingTaxAmount
+		if payLoan.CreatedAt.Before(now) {
+			arrears += payLoan.Amount
+		}
+	}
+
+	if outstanding == 0 {
+		return entity.LoanRestructureEntity{}, ErrNoRemainingBalance
+	}
+
+	// new schedule start one period after today, pushed by payment holiday
+	start := now.Add(commons.DifferentTime * time.Duration(data.HolidayPeriods+1))
+	var newPayLoans []entity.PayLoanEntity
+	if data.CapitalizeArrears || arrears == 0 {
+		newPayLoans = newPaySchedule(loan.Id, outstanding/float64(data.Tenor), start, data.Tenor)
+	} else {
+		// arrears is not due before new schedule start, otherwise it is overdue again at next schedule task
+		newPayLoans = append(newPayLoans, newPaySchedule(loan.Id, arrears, start, 1)...)
+		newPayLoans = append(newPayLoans, newPaySchedule(loan.Id, (outstanding-arrears)/float64(data.Tenor), start, data.Tenor)...)
+	}
+
+	// due date on weekend or holiday moved based on product roll convention
+	err = s.rollSchedule(ctx, product, newPayLoans)
+	if err != nil {
+		return entity.LoanRestructureEntity{}, err
+	}
+
+	// unpaid fee and tax of old schedule spread over new schedule
+	for i := range newPayLoans {
+		newPayLoans[i].FeeAmount = fee / float64(len(newPayLoans))
+		newPayLoans[i].TaxAmount = feeTax / float64(len(newPayLoans))
+		newPayLoans[i].WithholdingTaxAmount = withholdingTax / float64(len(newPayLoans))
+	}
+	roundPayLoans(loan.Currency, newPayLoans)
+
+	// old schedule is superseded and new one inserted together, loan never left without unpaid installment
+	var restructure entity.LoanRestructureEntity
+	err = s.repo.Transaction.WithTransaction(ctx, func(repo *repository.Repository) error {
+		// keep old schedule for audit
+		err := repo.PayLoan.UpdateStatusByLoanId(ctx, loan.Id, commons.StatusPayLoanUnpayed, commons.StatusPayLoanSuperseded)
+		if err != nil {
+			return err
+		}
+
+		err = repo.PayLoan.BatchInsert(ctx, newPayLoans)
+		if err != nil {
+			return err
+		}
+
+		restructure, err = repo.LoanRestructure.Create(ctx, entity.LoanRestructureEntity{
+			LoanId:            loan.Id,
+			Outstanding:       outstanding,
+			Arrears:           arrears,
+			Tenor:             data.Tenor,
+			HolidayPeriods:    data.HolidayPeriods,
+			CapitalizeArrears: data.CapitalizeArrears,
+			CreatedAt:         now,
+		})
+		if err != nil {
+			return err
+		}
+
+		// restructured borrower is back on track
+		if user.Status == commons.StatusUserDeliquent {
+			return repo.User.UpdateUser(ctx, data.Username, commons.StatusUserActiveLoan)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.LoanRestructureEntity{}, err
+	}
+
+	return restructure, nil
+}
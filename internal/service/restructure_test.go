@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/calendar"
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_RestructureLoan(t *testing.T) {
+	t.Run("success restructure with capitalized arrears", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanRestructureRepoMock := mock_repositories.NewMockILoanRestructureRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:            userRepoMock,
+			Loan:            loaRepoMock,
+			PayLoan:         payLoanRepoMock,
+			LoanRestructure: loanRestructureRepoMock,
+			LoanProduct:     loanProductRepoMock,
+			Transaction:     transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserDeliquent,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id: 123,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code: commons.DefaultProductCode,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 1000, Status: commons.StatusPayLoanPayed, CreatedAt: time.Now().Add(-3 * commons.DifferentTime)},
+			{Id: 2, LoanId: 123, Amount: 1000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(-2 * commons.DifferentTime)},
+			{Id: 3, LoanId: 123, Amount: 1000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(-1 * commons.DifferentTime)},
+			{Id: 4, LoanId: 123, Amount: 1000, FeeAmount: 20, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(commons.DifferentTime)},
+			{Id: 5, LoanId: 123, Amount: 1000, FeeAmount: 20, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(2 * commons.DifferentTime)},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanSuperseded).Return(nil)
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 8)
+			for _, payLoan := range payLoans {
+				assert.Equal(t, float64(500), payLoan.Amount)
+				assert.Equal(t, float64(5), payLoan.FeeAmount)
+				assert.True(t, payLoan.CreatedAt.After(time.Now().Add(2*commons.DifferentTime)))
+			}
+			return nil
+		})
+
+		loanRestructureRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, data entity.LoanRestructureEntity) (entity.LoanRestructureEntity, error) {
+			assert.Equal(t, float64(4000), data.Outstanding)
+			assert.Equal(t, float64(2000), data.Arrears)
+			data.Id = 1
+			return data, nil
+		})
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+
+		restructure, err := service.RestructureLoan(context.Background(), RestructureLoanEntity{
+			Username:          "user123",
+			Tenor:             8,
+			HolidayPeriods:    2,
+			CapitalizeArrears: true,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, restructure.Id)
+	})
+
+	t.Run("success restructure with arrears due with first rolled installment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanRestructureRepoMock := mock_repositories.NewMockILoanRestructureRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		holidayRepoMock := mock_repositories.NewMockIHolidayRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:            userRepoMock,
+			Loan:            loaRepoMock,
+			PayLoan:         payLoanRepoMock,
+			LoanRestructure: loanRestructureRepoMock,
+			LoanProduct:     loanProductRepoMock,
+			Holiday:         holidayRepoMock,
+			Transaction:     transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			ProductCode: "WEEKLY_ID",
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "WEEKLY_ID").Return(entity.LoanProductEntity{
+			Code:           "WEEKLY_ID",
+			Country:        "ID",
+			RollConvention: commons.RollConventionFollowing,
+		}, nil)
+
+		start := time.Now().Add(commons.DifferentTime)
+		holidays := []time.Time{start, start.AddDate(0, 0, 1)}
+		holidayRepoMock.EXPECT().GetByCountry(gomock.Any(), "ID").Return([]entity.HolidayEntity{
+			{Country: "ID", Date: holidays[0]},
+			{Country: "ID", Date: holidays[1]},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 2, LoanId: 123, Amount: 1000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(-1 * commons.DifferentTime)},
+			{Id: 3, LoanId: 123, Amount: 1000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(commons.DifferentTime)},
+			{Id: 4, LoanId: 123, Amount: 1000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(2 * commons.DifferentTime)},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanSuperseded).Return(nil)
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 5)
+			assert.Equal(t, float64(1000), payLoans[0].Amount)
+			for _, payLoan := range payLoans[1:] {
+				assert.Equal(t, float64(500), payLoan.Amount)
+			}
+
+			// arrears due together with first installment, both rolled past the holidays
+			cal := calendar.NewCalendar("ID", holidays)
+			assert.Equal(t, payLoans[1].CreatedAt, payLoans[0].CreatedAt)
+			assert.True(t, payLoans[0].CreatedAt.After(start.AddDate(0, 0, 1)))
+			for _, payLoan := range payLoans {
+				assert.True(t, cal.IsBusinessDay(payLoan.CreatedAt))
+			}
+			return nil
+		})
+
+		loanRestructureRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, data entity.LoanRestructureEntity) (entity.LoanRestructureEntity, error) {
+			return data, nil
+		})
+
+		_, err := service.RestructureLoan(context.Background(), RestructureLoanEntity{
+			Username: "user123",
+			Tenor:    4,
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error invalid tenor", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.RestructureLoan(context.Background(), RestructureLoanEntity{
+			Username: "user123",
+			Tenor:    0,
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "tenor must be greater than zero")
+	})
+
+	t.Run("error user not on open loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserClosedLoan,
+		}, nil)
+
+		_, err := service.RestructureLoan(context.Background(), RestructureLoanEntity{
+			Username: "user123",
+			Tenor:    4,
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "user not on open loan")
+	})
+}
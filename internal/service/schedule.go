@@ -0,0 +1,71 @@
+package service
+
+import (
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+// newPaySchedule build unpaid installments for loan, first installment due at start
+// and next installment due every commons.DifferentTime
+func newPaySchedule(loanId int, amountPerPay float64, start time.Time, count int) []entity.PayLoanEntity {
+	payLoanEntities := []entity.PayLoanEntity{}
+
+	timePay := start
+	for i := 0; i < count; i++ {
+		payLoanEntities = append(payLoanEntities, entity.PayLoanEntity{
+			LoanId:    loanId,
+			Amount:    amountPerPay,
+			CreatedAt: timePay,
+			Status:    commons.StatusPayLoanUnpayed,
+		})
+
+		timePay = timePay.Add(commons.DifferentTime)
+	}
+
+	return payLoanEntities
+}
+
+// newLoanSchedule build installments of new loan based on product, schedule start after product grace period.
+// it return the schedule and interest of grace period that charged to borrower
+func newLoanSchedule(product entity.LoanProductEntity, amount float64, createdAt time.Time) ([]entity.PayLoanEntity, float64) {
+	amountPerPay := amount / float64(product.Tenor)
+	interestPerPay := amountPerPay * product.Interest / 100
+	amountPerPayAfterInterest := amountPerPay + interestPerPay
+
+	start := createdAt.Add(commons.DifferentTime * time.Duration(product.GracePeriods))
+	graceInterest := interestPerPay * float64(product.GracePeriods)
+
+	switch product.GraceInterest {
+	case commons.InterestHandlingCapitalized:
+		amountPerPayAfterInterest += graceInterest / float64(product.Tenor)
+	case commons.InterestHandlingAccrued:
+	default:
+		graceInterest = 0
+	}
+
+	payLoans := newPaySchedule(0, amountPerPayAfterInterest, start, product.Tenor)
+	addInstallmentFees(product, payLoans)
+
+	if product.GraceInterest == commons.InterestHandlingAccrued && graceInterest > 0 {
+		lastDue := payLoans[len(payLoans)-1].CreatedAt
+		payLoans = append(payLoans, newPaySchedule(0, graceInterest, lastDue.Add(commons.DifferentTime), 1)...)
+	}
+
+	return payLoans, graceInterest
+}
+
+// interestPortion return interest part of installment amount
+func interestPortion(amount float64, interest float64) float64 {
+	return amount * interest / (100 + interest)
+}
+
+func isValidInterestHandling(handling int) bool {
+	switch handling {
+	case commons.InterestHandlingWaived, commons.InterestHandlingAccrued, commons.InterestHandlingCapitalized:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+const (
+	scheduleTaskPageSize   = 500
+	scheduleTaskWorkers    = 8
+	scheduleTaskAttempts   = 3
+	scheduleTaskRetryDelay = 100 * time.Millisecond
+)
+
+// outcome of checking one open loan
+const (
+	loanTaskUnchanged = iota
+	loanTaskClosed
+	loanTaskDelinquent
+	loanTaskWrittenOff
+)
+
+// ScheduleTaskFailure is loan that still failed after every retry
+type ScheduleTaskFailure struct {
+	LoanId int
+	Err    error
+}
+
+// ScheduleTaskSummary count what happened to open loans in one run
+type ScheduleTaskSummary struct {
+	Processed  int
+	Closed     int
+	Delinquent int
+	WrittenOff int
+	Failed     int
+	Failures   []ScheduleTaskFailure
+}
+
+func (summary *ScheduleTaskSummary) record(loanId int, outcome int, err error) {
+	summary.Processed++
+
+	if err != nil {
+		summary.Failed++
+		summary.Failures = append(summary.Failures, ScheduleTaskFailure{LoanId: loanId, Err: err})
+		return
+	}
+
+	switch outcome {
+	case loanTaskClosed:
+		summary.Closed++
+	case loanTaskDelinquent:
+		summary.Delinquent++
+	case loanTaskWrittenOff:
+		summary.WrittenOff++
+	}
+}
+
+// loanTask is what to do with one open loan, err is set when it can not be decided
+type loanTask struct {
+	loan    entity.LoanEntity
+	product entity.LoanProductEntity
+	outcome int
+	err     error
+}
+
+// ScheduleTask scan open loan page by page, decide every loan of the page with few aggregated query and apply
+// the change with pool of worker. Loan is closed when fully paid, written off when past due too long and
+// borrower flagged delinquent when two installment late. Failed loan is retried and then counted in summary
+// without stopping other loan, error only returned when scanning loan failed or context cancelled
+func (s *Service) ScheduleTask(ctx context.Context) (ScheduleTaskSummary, error) {
+	now := s.clock.Now()
+	roller := s.newDueDateRoller()
+
+	tasks := make(chan loanTask)
+	summary := ScheduleTaskSummary{}
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < scheduleTaskWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for task := range tasks {
+				err := task.err
+				if err == nil {
+					err = s.applyLoanTaskWithRetry(ctx, task, now)
+				}
+
+				mu.Lock()
+				summary.record(task.loan.Id, task.outcome, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	err := s.scanOpenLoans(ctx, roller, now, tasks)
+	close(tasks)
+	wg.Wait()
+
+	return summary, err
+}
+
+// scanOpenLoans send task of every open loan to tasks, keyset on id so every loan is visited once
+func (s *Service) scanOpenLoans(ctx context.Context, roller *dueDateRoller, now time.Time, tasks chan<- loanTask) error {
+	afterId := 0
+
+	for {
+		page, err := s.repo.Loan.GetByStatusAfterId(ctx, commons.StatusLoanNew, afterId, scheduleTaskPageSize)
+		if err != nil {
+			return err
+		}
+
+		pageTasks, err := s.decideLoanTasks(ctx, roller, page, now)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range pageTasks {
+			select {
+			case tasks <- task:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if len(page) < scheduleTaskPageSize {
+			return nil
+		}
+		afterId = page[len(page)-1].Id
+	}
+}
+
+// decideLoanTasks decide task of every loan with fully paid and overdue count of all loans queried at once.
+// Overdue installment is only loaded for loan of product that roll due date to business day,
+// because installment due on holiday is not late yet
+func (s *Service) decideLoanTasks(ctx context.Context, roller *dueDateRoller, loans []entity.LoanEntity, now time.Time) ([]loanTask, error) {
+	loanIds := []int{}
+	for _, loan := range loans {
+		loanIds = append(loanIds, loan.Id)
+	}
+
+	paidLoanIds, err := s.repo.PayLoan.GetFullyPaidLoanIds(ctx, loanIds)
+	if err != nil {
+		return nil, err
+	}
+
+	paid := map[int]bool{}
+	for _, loanId := range paidLoanIds {
+		paid[loanId] = true
+	}
+
+	overdues, err := s.repo.PayLoan.CountOverdueByLoanIds(ctx, loanIds, now)
+	if err != nil {
+		return nil, err
+	}
+
+	overdueByLoan := map[int]entity.OverdueEntity{}
+	for _, overdue := range overdues {
+		overdueByLoan[overdue.LoanId] = overdue
+	}
+
+	tasks := make([]loanTask, len(loans))
+	rolled := []int{}
+	for i, loan := range loans {
+		tasks[i].loan = loan
+
+		if paid[loan.Id] {
+			tasks[i].outcome = loanTaskClosed
+			continue
+		}
+
+		overdue, ok := overdueByLoan[loan.Id]
+		if !ok {
+			continue
+		}
+
+		tasks[i].product, tasks[i].err = roller.product(ctx, loan.ProductCode)
+		if tasks[i].err != nil {
+			continue
+		}
+
+		if tasks[i].product.RollConvention != commons.RollConventionNone {
+			rolled = append(rolled, i)
+			continue
+		}
+
+		tasks[i].outcome = overdueOutcome(tasks[i].product, overdue.OverdueCount, overdue.OldestDueDate, now)
+	}
+
+	if len(rolled) == 0 {
+		return tasks, nil
+	}
+
+	rolledLoanIds := []int{}
+	for _, i := range rolled {
+		rolledLoanIds = append(rolledLoanIds, tasks[i].loan.Id)
+	}
+
+	payLoans, err := s.repo.PayLoan.GetOverdueByLoanIds(ctx, rolledLoanIds, now)
+	if err != nil {
+		return nil, err
+	}
+
+	payLoansByLoan := map[int][]entity.PayLoanEntity{}
+	for _, payLoan := range payLoans {
+		payLoansByLoan[payLoan.LoanId] = append(payLoansByLoan[payLoan.LoanId], payLoan)
+	}
+
+	for _, i := range rolled {
+		// installment only late when the due date after rolled to business day already passed
+		late := 0
+		oldestDueDate := now
+		for _, payLoan := range payLoansByLoan[tasks[i].loan.Id] {
+			dueDate, err := roller.roll(ctx, tasks[i].loan.ProductCode, payLoan.CreatedAt)
+			if err != nil {
+				tasks[i].err = err
+				break
+			}
+
+			if dueDate.Before(now) {
+				late++
+			}
+
+			if dueDate.Before(oldestDueDate) {
+				oldestDueDate = dueDate
+			}
+		}
+
+		if tasks[i].err == nil {
+			tasks[i].outcome = overdueOutcome(tasks[i].product, late, oldestDueDate, now)
+		}
+	}
+
+	return tasks, nil
+}
+
+// overdueOutcome write off loan that past due too long, otherwise flag borrower delinquent when two installment late
+func overdueOutcome(product entity.LoanProductEntity, late int, oldestDueDate time.Time, now time.Time) int {
+	if product.WriteOffDays > 0 && now.Sub(oldestDueDate) >= time.Duration(product.WriteOffDays)*24*time.Hour {
+		return loanTaskWrittenOff
+	}
+
+	if late >= 2 {
+		return loanTaskDelinquent
+	}
+
+	return loanTaskUnchanged
+}
+
+func (s *Service) applyLoanTaskWithRetry(ctx context.Context, task loanTask, now time.Time) error {
+	var err error
+
+	for attempt := 1; attempt <= scheduleTaskAttempts; attempt++ {
+		err = s.applyLoanTask(ctx, task, now)
+		if err == nil {
+			return nil
+		}
+
+		if attempt == scheduleTaskAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(time.Duration(attempt) * scheduleTaskRetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("loan %d failed after %d attempt: %w", task.loan.Id, scheduleTaskAttempts, err)
+}
+
+// applyLoanTask close, write off or flag borrower of one open loan
+func (s *Service) applyLoanTask(ctx context.Context, task loanTask, now time.Time) error {
+	switch task.outcome {
+	case loanTaskClosed:
+		// update loan status to closed
+		err := s.repo.Loan.UpdateStatus(ctx, task.loan.Id, commons.StatusLoanClosed)
+		if err != nil {
+			return err
+		}
+
+		// update user status to closed loan
+		return s.repo.User.UpdateUser(ctx, task.loan.Username, commons.StatusUserClosedLoan)
+	case loanTaskWrittenOff:
+		// loan that past due too long is charged off and not scanned anymore
+		_, err := s.writeOff(ctx, task.loan, task.product, fmt.Sprintf("auto write off after %d days past due", task.product.WriteOffDays), now)
+		if errors.Is(err, ErrLoanNotOpen) {
+			// already written off or closed by other run
+			return nil
+		}
+		return err
+	case loanTaskDelinquent:
+		// update user to delinquent
+		return s.repo.User.UpdateUser(ctx, task.loan.Username, commons.StatusUserDeliquent)
+	}
+
+	return nil
+}
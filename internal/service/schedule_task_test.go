@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// benchQueryLatency is round trip of one query to database
+const benchQueryLatency = 200 * time.Microsecond
+
+// benchDB is in memory portfolio that count query and wait round trip on every query
+type benchDB struct {
+	queries  atomic.Int64
+	loans    []entity.LoanEntity
+	payLoans map[int][]entity.PayLoanEntity
+}
+
+func (db *benchDB) query() {
+	db.queries.Add(1)
+	time.Sleep(benchQueryLatency)
+}
+
+type benchLoanRepository struct {
+	repository.ILoanRepository
+	db *benchDB
+}
+
+func (r *benchLoanRepository) GetByStatusAfterId(ctx context.Context, status int, afterId int, limit int) ([]entity.LoanEntity, error) {
+	r.db.query()
+
+	result := []entity.LoanEntity{}
+	for _, loan := range r.db.loans {
+		if loan.Id > afterId && len(result) < limit {
+			result = append(result, loan)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *benchLoanRepository) UpdateStatus(ctx context.Context, loanId int, status int) error {
+	r.db.query()
+	return nil
+}
+
+type benchPayLoanRepository struct {
+	repository.IPayLoanRepository
+	db *benchDB
+}
+
+func (r *benchPayLoanRepository) GetInSpecificTimeAndStatus(ctx context.Context, loanId int, timeNow time.Time) ([]entity.PayLoanEntity, error) {
+	r.db.query()
+
+	result := []entity.PayLoanEntity{}
+	for _, payLoan := range r.db.payLoans[loanId] {
+		if payLoan.Status == commons.StatusPayLoanUnpayed && payLoan.CreatedAt.Before(timeNow) {
+			result = append(result, payLoan)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *benchPayLoanRepository) CountOverdueByLoanIds(ctx context.Context, loanIds []int, timeNow time.Time) ([]entity.OverdueEntity, error) {
+	r.db.query()
+
+	result := []entity.OverdueEntity{}
+	for _, loanId := range loanIds {
+		overdue := entity.OverdueEntity{LoanId: loanId}
+		for _, payLoan := range r.db.payLoans[loanId] {
+			if payLoan.Status == commons.StatusPayLoanUnpayed && payLoan.CreatedAt.Before(timeNow) {
+				if overdue.OverdueCount == 0 || payLoan.CreatedAt.Before(overdue.OldestDueDate) {
+					overdue.OldestDueDate = payLoan.CreatedAt
+				}
+				overdue.OverdueCount++
+			}
+		}
+		if overdue.OverdueCount > 0 {
+			result = append(result, overdue)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *benchPayLoanRepository) GetFullyPaidLoanIds(ctx context.Context, loanIds []int) ([]int, error) {
+	r.db.query()
+
+	result := []int{}
+	for _, loanId := range loanIds {
+		paid := true
+		for _, payLoan := range r.db.payLoans[loanId] {
+			if payLoan.Status == commons.StatusPayLoanUnpayed {
+				paid = false
+			}
+		}
+		if paid {
+			result = append(result, loanId)
+		}
+	}
+
+	return result, nil
+}
+
+type benchUserRepository struct {
+	repository.IUserRepository
+	db *benchDB
+}
+
+func (r *benchUserRepository) UpdateUser(ctx context.Context, username string, status int) error {
+	r.db.query()
+	return nil
+}
+
+type benchLoanProductRepository struct {
+	repository.ILoanProductRepository
+	db *benchDB
+}
+
+func (r *benchLoanProductRepository) Get(ctx context.Context, code string) (entity.LoanProductEntity, error) {
+	r.db.query()
+	return entity.LoanProductEntity{Code: code}, nil
+}
+
+// newBenchService build portfolio of loans with 50 weekly installment, a quarter of them fully paid,
+// a quarter two installment late and the rest on time
+func newBenchService(loans int) (*Service, *benchDB) {
+	db := &benchDB{payLoans: map[int][]entity.PayLoanEntity{}}
+	now := time.Now()
+
+	for id := 1; id <= loans; id++ {
+		db.loans = append(db.loans, entity.LoanEntity{Id: id, Username: "user", ProductCode: commons.DefaultProductCode})
+
+		paid := 10
+		switch id % 4 {
+		case 0:
+			paid = 50
+		case 1:
+			paid = 8
+		}
+
+		for week := 0; week < 50; week++ {
+			status := commons.StatusPayLoanUnpayed
+			if week < paid {
+				status = commons.StatusPayLoanPayed
+			}
+
+			db.payLoans[id] = append(db.payLoans[id], entity.PayLoanEntity{
+				LoanId:    id,
+				Status:    status,
+				CreatedAt: now.AddDate(0, 0, 7*(week-9)),
+			})
+		}
+	}
+
+	service := &Service{repo: &repository.Repository{
+		Loan:        &benchLoanRepository{db: db},
+		PayLoan:     &benchPayLoanRepository{db: db},
+		User:        &benchUserRepository{db: db},
+		LoanProduct: &benchLoanProductRepository{db: db},
+	}, clock: clock.System}
+
+	return service, db
+}
+
+// scheduleTaskPerLoan is schedule task before overdue was aggregated, one query of installment for every loan
+func (s *Service) scheduleTaskPerLoan(ctx context.Context) error {
+	now := time.Now()
+	roller := s.newDueDateRoller()
+
+	loans := make(chan entity.LoanEntity)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < scheduleTaskWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for loan := range loans {
+				payLoans, _ := s.repo.PayLoan.GetInSpecificTimeAndStatus(ctx, loan.Id, now)
+				if len(payLoans) == 0 {
+					_ = s.repo.Loan.UpdateStatus(ctx, loan.Id, commons.StatusLoanClosed)
+					_ = s.repo.User.UpdateUser(ctx, loan.Username, commons.StatusUserClosedLoan)
+					continue
+				}
+
+				late := 0
+				for _, payLoan := range payLoans {
+					dueDate, _ := roller.roll(ctx, loan.ProductCode, payLoan.CreatedAt)
+					if dueDate.Before(now) {
+						late++
+					}
+				}
+
+				if late >= 2 {
+					_ = s.repo.User.UpdateUser(ctx, loan.Username, commons.StatusUserDeliquent)
+				}
+			}
+		}()
+	}
+
+	afterId := 0
+	for {
+		page, err := s.repo.Loan.GetByStatusAfterId(ctx, commons.StatusLoanNew, afterId, scheduleTaskPageSize)
+		if err != nil {
+			close(loans)
+			wg.Wait()
+			return err
+		}
+
+		for _, loan := range page {
+			loans <- loan
+		}
+
+		if len(page) < scheduleTaskPageSize {
+			break
+		}
+		afterId = page[len(page)-1].Id
+	}
+
+	close(loans)
+	wg.Wait()
+
+	return nil
+}
+
+func BenchmarkScheduleTask(b *testing.B) {
+	b.Run("per loan", func(b *testing.B) {
+		service, db := newBenchService(2000)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := service.scheduleTaskPerLoan(context.Background()); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.ReportMetric(float64(db.queries.Load())/float64(b.N), "queries/op")
+	})
+
+	b.Run("aggregated", func(b *testing.B) {
+		service, db := newBenchService(2000)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := service.ScheduleTask(context.Background()); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.ReportMetric(float64(db.queries.Load())/float64(b.N), "queries/op")
+	})
+}
+
+func TestService_ScheduleTask_Portfolio(t *testing.T) {
+	service, _ := newBenchService(8)
+
+	summary, err := service.ScheduleTask(context.Background())
+
+	// loan with 8 paid installment has installment due last week and today, loan with 10 paid is due next week
+	assert.Nil(t, err)
+	assert.Equal(t, ScheduleTaskSummary{Processed: 8, Closed: 2, Delinquent: 2}, summary)
+
+	t.Run("late after two simulated week", func(t *testing.T) {
+		simulated := clock.NewSimulated(time.Now())
+		service.clock = simulated
+
+		_, err := simulated.Advance(2*commons.DifferentTime + time.Hour)
+		assert.Nil(t, err)
+
+		summary, err := service.ScheduleTask(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, ScheduleTaskSummary{Processed: 8, Closed: 2, Delinquent: 6}, summary)
+	})
+}
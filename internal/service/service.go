@@ -4,126 +4,218 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"time"
 
+	"github.com/billing-engine/internal/clock"
 	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
 	"github.com/billing-engine/internal/repository"
 	"github.com/billing-engine/internal/repository/entity"
 )
 
 type CreateLoanEntity struct {
-	Username string
+	Username    string
+	Amount      float64
+	ProductCode string
+	PromoCode   string
+}
+
+// OutstandingEntity split unpaid loan amount (principal and interest), unpaid fee and its tax
+type OutstandingEntity struct {
+	Currency string
 	Amount   float64
+	Fee      float64
+	Tax      float64
+	Total    float64
 }
 
 type MakePaymentEntity struct {
 	Username string
 	Amount   float64
+	Currency string
+}
+
+// reason code of rejected payment
+const (
+	PaymentReasonUserNotActiveLoan = "USER_NOT_ACTIVE_LOAN"
+	PaymentReasonNoActiveLoan      = "NO_ACTIVE_LOAN"
+	PaymentReasonCurrencyMismatch  = "CURRENCY_MISMATCH"
+	PaymentReasonAlreadyPaid       = "ALREADY_PAID"
+	PaymentReasonAmountMismatch    = "AMOUNT_MISMATCH"
+)
+
+// PaymentResult is outcome of payment, refused payment is not an error but rejected with reason code.
+// InstallmentsSettled, Outstanding and NextDueDate describe the loan after payment, NextDueDate is zero when nothing left to pay
+type PaymentResult struct {
+	Accepted            bool
+	ReasonCode          string
+	Message             string
+	InstallmentsSettled int
+	Outstanding         float64
+	NextDueDate         time.Time
+}
+
+func rejectPayment(reasonCode string, message string) PaymentResult {
+	return PaymentResult{
+		ReasonCode: reasonCode,
+		Message:    message,
+	}
 }
 
 type Service struct {
-	repo *repository.Repository
+	repo  *repository.Repository
+	clock clock.Clock
 }
 
 type ServiceInterface interface {
-	ScheduleTask(ctx context.Context) error
-	GetOutStanding(ctx context.Context, username string) (float64, error)
+	ScheduleTask(ctx context.Context) (ScheduleTaskSummary, error)
+	GetOutStanding(ctx context.Context, username string) (OutstandingEntity, error)
 	CreateLoan(ctx context.Context, data CreateLoanEntity) error
 	IsDelinquent(ctx context.Context, username string) (bool, error)
-	MakePayment(ctx context.Context, data MakePaymentEntity) (string, error)
+	MakePayment(ctx context.Context, data MakePaymentEntity) (PaymentResult, error)
+	CreateBorrower(ctx context.Context, data CreateBorrowerEntity) (entity.UserEntity, error)
+	UpdateBorrower(ctx context.Context, data UpdateBorrowerEntity) (entity.UserEntity, error)
+	ReviewKyc(ctx context.Context, data ReviewKycEntity) (entity.UserEntity, error)
+	GetBorrower(ctx context.Context, username string) (entity.UserEntity, error)
+	RestructureLoan(ctx context.Context, data RestructureLoanEntity) (entity.LoanRestructureEntity, error)
+	GrantPaymentHoliday(ctx context.Context, data PaymentHolidayEntity) ([]entity.PayLoanEntity, error)
+	WriteOffLoan(ctx context.Context, data WriteOffLoanEntity) (entity.LoanEntity, error)
+	MakeRecoveryPayment(ctx context.Context, data MakePaymentEntity) (entity.LoanEntity, error)
+	CancelLoan(ctx context.Context, data CancelLoanEntity) (entity.LoanEntity, error)
+	RefinanceLoan(ctx context.Context, data RefinanceLoanEntity) (RefinanceLoanResult, error)
+	GetTaxSummary(ctx context.Context, from time.Time, to time.Time) ([]TaxSummaryEntity, error)
+	QuoteLoan(ctx context.Context, data QuoteLoanEntity) (LoanQuoteEntity, error)
+	GetPortfolio(ctx context.Context) ([]PortfolioEntity, error)
+	GetLoan(ctx context.Context, loanId int) (entity.LoanEntity, error)
+	GetLoanSchedule(ctx context.Context, loanId int) (LoanScheduleEntity, error)
+	GetBorrowerSchedule(ctx context.Context, username string) (LoanScheduleEntity, error)
+	ListLoans(ctx context.Context, query HistoryQuery) (LoanHistoryEntity, error)
+	ListPayments(ctx context.Context, query HistoryQuery) (PaymentHistoryEntity, error)
+	GetPortfolioReport(ctx context.Context, date time.Time) (PortfolioReportEntity, error)
+	SnapshotPortfolioReport(ctx context.Context, date time.Time) error
+	ValidateExport(query ExportQuery) error
+	Export(ctx context.Context, query ExportQuery, w io.Writer) error
+	GetStatement(ctx context.Context, query StatementQuery) (StatementDocument, error)
+	GenerateMonthlyStatements(ctx context.Context, month time.Time) (int, error)
 }
 
-func NewService(repo *repository.Repository) ServiceInterface {
+// NewService create service reading current time from clk, clock.System outside simulation
+func NewService(repo *repository.Repository, clk clock.Clock) ServiceInterface {
 	return &Service{
-		repo: repo,
+		repo:  repo,
+		clock: clk,
 	}
 }
 
-func (s *Service) ScheduleTask(ctx context.Context) error {
-	// check all open loan from users
-	openLoans, err := s.repo.Loan.GetByStatus(ctx, commons.StatusLoanNew)
-	if err != nil {
-		return err
+func (s *Service) MakePayment(ctx context.Context, data MakePaymentEntity) (PaymentResult, error) {
+	if data.Currency == "" {
+		return PaymentResult{}, ErrCurrencyRequired
 	}
 
-	// check every loan that open / closed pay_loan
-	for _, openLoan := range openLoans {
-		payLoans, err := s.repo.PayLoan.GetInSpecificTimeAndStatus(ctx, openLoan.Id, time.Now())
-		if err != nil {
-			return err
-		}
-
-		if len(payLoans) == 0 {
-			// update loan status to closed
-			err = s.repo.Loan.UpdateStatus(ctx, openLoan.Id, commons.StatusLoanClosed)
-			if err != nil {
-				return err
-			}
-
-			// update user status to closed loan
-			err = s.repo.User.UpdateUser(ctx, openLoan.Username, commons.StatusUserClosedLoan)
-			if err != nil {
-				return err
-			}
-		} else if len(payLoans) >= 2 {
-			// update user to delinquent
-			err = s.repo.User.UpdateUser(ctx, openLoan.Username, commons.StatusUserDeliquent)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func (s *Service) MakePayment(ctx context.Context, data MakePaymentEntity) (string, error) {
 	// check user have loan
 	user, err := s.repo.User.GetUser(ctx, data.Username)
 	if err != nil {
-		return "", err
+		return PaymentResult{}, err
 	}
 
 	if user.Status != commons.StatusUserActiveLoan {
-		return "user not active loan", nil
+		return rejectPayment(PaymentReasonUserNotActiveLoan, "user not active loan"), nil
 	}
 
 	// get loan
-	loan, err := s.repo.Loan.Get(ctx, data.Username, commons.StatusLoanNew)
+	loan, err := s.getLoan(ctx, data.Username, commons.StatusLoanNew)
+	if errors.Is(err, ErrLoanNotFound) {
+		return rejectPayment(PaymentReasonNoActiveLoan, "not have any active loan"), nil
+	}
 	if err != nil {
-		return "", err
+		return PaymentResult{}, err
 	}
 
 	if loan.Id == 0 {
-		return "not have any active loan", nil
+		return rejectPayment(PaymentReasonNoActiveLoan, "not have any active loan"), nil
+	}
+
+	if !isSameCurrency(loan, data.Currency) {
+		return rejectPayment(PaymentReasonCurrencyMismatch, fmt.Sprintf("payment currency not same with loan currency : %s", loan.Currency)), nil
 	}
 
-	payloans, err := s.repo.PayLoan.GetInSpecificTimeAndStatus(ctx, loan.Id, time.Now())
+	payloans, err := s.repo.PayLoan.GetInSpecificTimeAndStatus(ctx, loan.Id, s.clock.Now())
 	if err != nil {
-		return "", err
+		return PaymentResult{}, err
 	}
 
 	if len(payloans) == 0 {
-		return "already payed for this week", nil
+		return rejectPayment(PaymentReasonAlreadyPaid, "already payed for this week"), nil
 	}
 
 	sort.Slice(payloans, func(i, j int) bool {
 		return payloans[i].CreatedAt.Before(payloans[j].CreatedAt)
 	})
 
-	if payloans[0].Amount != data.Amount {
-		return fmt.Sprintf("amount not same with requirment : %.2f", payloans[0].Amount), nil
+	due := currency.Round(loan.Currency, installmentDue(payloans[0]))
+	if due != currency.Round(loan.Currency, data.Amount) {
+		return rejectPayment(PaymentReasonAmountMismatch, fmt.Sprintf("amount not same with requirment : %.*f", currency.Decimals(loan.Currency), due)), nil
 	}
 
 	err = s.repo.PayLoan.Update(ctx, payloans[0].Id, entity.PayLoanEntity{
-		Status: commons.StatusPayLoanPayed,
+		Status:     commons.StatusPayLoanPayed,
+		PaidAt:     s.clock.Now(),
+		PaidAmount: data.Amount,
 	})
 	if err != nil {
-		return "", err
+		return PaymentResult{}, err
 	}
 
-	return "success make payment", nil
+	allPayLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+	if err != nil {
+		return PaymentResult{}, err
+	}
+
+	return PaymentResult{
+		Accepted:            true,
+		Message:             "success make payment",
+		InstallmentsSettled: countPaid(allPayLoans),
+		Outstanding:         currency.Round(loan.Currency, calculateOutstanding(loan, allPayLoans).Total),
+		NextDueDate:         nextDueDate(allPayLoans),
+	}, nil
+}
+
+// countPaid return number of installment already paid
+func countPaid(payLoans []entity.PayLoanEntity) int {
+	paid := 0
+	for _, payLoan := range payLoans {
+		if payLoan.Status == commons.StatusPayLoanPayed {
+			paid++
+		}
+	}
+
+	return paid
+}
+
+// nextDueDate return due date of earliest unpaid installment, zero when all installment settled
+func nextDueDate(payLoans []entity.PayLoanEntity) time.Time {
+	next, _ := nextInstallment(payLoans)
+	return next.CreatedAt
+}
+
+// nextInstallment return earliest unpaid installment, false when all installment settled
+func nextInstallment(payLoans []entity.PayLoanEntity) (entity.PayLoanEntity, bool) {
+	next := entity.PayLoanEntity{}
+	found := false
+	for _, payLoan := range payLoans {
+		if payLoan.Status != commons.StatusPayLoanUnpayed {
+			continue
+		}
+
+		if !found || payLoan.CreatedAt.Before(next.CreatedAt) {
+			next = payLoan
+			found = true
+		}
+	}
+
+	return next, found
 }
 
 func (s *Service) IsDelinquent(ctx context.Context, username string) (bool, error) {
@@ -132,106 +224,241 @@ func (s *Service) IsDelinquent(ctx context.Context, username string) (bool, erro
 		return false, err
 	}
 
+	if user.Username == "" {
+		return false, ErrUserNotRegistered
+	}
+
 	return user.Status == commons.StatusUserDeliquent, nil
 }
 
 func (s *Service) CreateLoan(ctx context.Context, data CreateLoanEntity) error {
-	var user entity.UserEntity
-	var err error
-
 	// check user active loan or not
 	// validate one user only can make one loan
-	user, err = s.repo.User.GetUser(ctx, data.Username)
+	user, err := s.repo.User.GetUser(ctx, data.Username)
 	if err != nil {
 		return err
 	}
 
 	if user.Username == "" {
-		userCreate, err := s.repo.User.Create(ctx, data.Username)
-		if err != nil {
-			return err
-		}
+		return ErrUserNotRegistered
+	}
 
-		user = userCreate
+	// only borrower with verified kyc can make loan
+	if user.KycStatus != commons.StatusKycVerified {
+		return ErrKycNotVerified
 	}
 
 	if user.Status == commons.StatusUserActiveLoan {
-		return errors.New("user have other active loan")
+		return ErrUserHasActiveLoan
 	}
 
-	// create loan data
-	loan, err := s.repo.Loan.CreateLoan(ctx, entity.LoanEntity{
-		Username: data.Username,
-		// amount that saved on loan after add interest fee
-		Amount:    data.Amount + data.Amount*commons.FlatInterest/100,
-		CreatedAt: time.Now(),
-		Status:    commons.StatusLoanNew,
-	})
+	product, err := s.getLoanProduct(ctx, data.ProductCode)
 	if err != nil {
 		return err
 	}
 
-	// create pay_loan data for several weeks payment
-	amountPerPay := loan.Amount / commons.CountWeekPay
-	timePay := loan.CreatedAt
-	amountPerPayAfterInterest := amountPerPay*commons.FlatInterest/100 + amountPerPay
-	payLoanEntities := []entity.PayLoanEntity{}
-	for i := 0; i < commons.CountWeekPay; i++ {
-		payLoanEntities = append(payLoanEntities, entity.PayLoanEntity{
-			LoanId:    loan.Id,
-			Amount:    amountPerPayAfterInterest,
-			CreatedAt: timePay,
-			Status:    commons.StatusPayLoanUnpayed,
-		})
-
-		timePay = timePay.Add(commons.DifferentTime)
-	}
-
-	err = s.repo.PayLoan.BatchInsert(ctx, payLoanEntities)
+	promo, err := s.getPromo(ctx, data.PromoCode, product, data.Amount, user)
 	if err != nil {
 		return err
 	}
 
-	// update user status
-	err = s.repo.User.UpdateUser(ctx, data.Username, commons.StatusUserActiveLoan)
+	draft, err := s.draftLoan(ctx, data.Username, data.Amount, product, promo)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.repo.Transaction.WithTransaction(ctx, func(repo *repository.Repository) error {
+		_, err := s.bookLoan(ctx, repo, draft)
+		if err != nil {
+			return err
+		}
+
+		// update user status
+		return repo.User.UpdateUser(ctx, data.Username, commons.StatusUserActiveLoan)
+	})
 }
 
-func (s *Service) GetOutStanding(ctx context.Context, username string) (float64, error) {
+func (s *Service) GetOutStanding(ctx context.Context, username string) (OutstandingEntity, error) {
 	// get users with status loan
 	user, err := s.repo.User.GetUser(ctx, username)
 	if err != nil {
-		return 0, err
+		return OutstandingEntity{}, err
+	}
+
+	if user.Username == "" {
+		return OutstandingEntity{}, ErrUserNotRegistered
 	}
 
 	if user.Status != commons.StatusUserActiveLoan {
-		return 0, errors.New("user not on open loan")
+		return OutstandingEntity{}, ErrUserNotOnOpenLoan
 	}
 
 	// get loan data
-	loan, err := s.repo.Loan.Get(ctx, username, commons.StatusLoanNew)
+	loan, err := s.getLoan(ctx, username, commons.StatusLoanNew)
 	if err != nil {
-		return 0, err
+		return OutstandingEntity{}, err
 	}
 
 	// get loan_pay
 	payLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
 	if err != nil {
-		return 0, err
+		return OutstandingEntity{}, err
 	}
 
 	// calculate out standing
+	return calculateOutstanding(loan, payLoans), nil
+}
+
+func (s *Service) getLoanProduct(ctx context.Context, code string) (entity.LoanProductEntity, error) {
+	if code == "" {
+		code = commons.DefaultProductCode
+	}
+
+	product, err := s.repo.LoanProduct.Get(ctx, code)
+	if err != nil {
+		return entity.LoanProductEntity{}, err
+	}
+
+	if product.Code == "" {
+		return entity.LoanProductEntity{}, ErrLoanProductNotFound
+	}
+
+	return product, nil
+}
+
+// validateLoanAmount check principal against amount limit of product and minor unit of its currency
+func validateLoanAmount(product entity.LoanProductEntity, principal float64) error {
+	if principal <= 0 {
+		return ErrInvalidAmount
+	}
+
+	if product.MinAmount > 0 && principal < product.MinAmount {
+		return errAmountBelowMinimum(product)
+	}
+
+	if product.MaxAmount > 0 && principal > product.MaxAmount {
+		return errAmountAboveMaximum(product)
+	}
+
+	if currency.Round(product.Currency, principal) != principal {
+		return errAmountPrecision(product)
+	}
+
+	return nil
+}
+
+// loanDraft is loan with its installment schedule that not yet saved
+type loanDraft struct {
+	Loan     entity.LoanEntity
+	PayLoans []entity.PayLoanEntity
+}
+
+// draftLoan calculate loan and its installment schedule for user based on product and promo
+func (s *Service) draftLoan(ctx context.Context, username string, principal float64, product entity.LoanProductEntity, promo entity.PromoEntity) (loanDraft, error) {
+	err := validateLoanAmount(product, principal)
+	if err != nil {
+		return loanDraft{}, err
+	}
+
+	t, err := s.getTax(ctx, product.Country)
+	if err != nil {
+		return loanDraft{}, err
+	}
+
+	// origination fee is deducted from disbursement or financed on principal
+	charge := newOriginationCharge(product, principal, t)
+
+	// amount that saved on loan after add interest fee
+	amount := charge.Principal + charge.Principal*product.Interest/100
+	createdAt := s.clock.Now()
+
+	// create pay_loan data for several weeks payment
+	payLoanEntities, graceInterest := newLoanSchedule(product, amount, createdAt)
+
+	// promo discount interest before tax withheld from it
+	discount := applyPromo(promo, product, payLoanEntities)
+	applyTax(t, product, payLoanEntities)
+
+	// due date on weekend or holiday moved based on product roll convention
+	err = s.rollSchedule(ctx, product, payLoanEntities)
+	if err != nil {
+		return loanDraft{}, err
+	}
+	roundPayLoans(product.Currency, payLoanEntities)
+
+	return loanDraft{
+		Loan: roundLoan(entity.LoanEntity{
+			Username:    username,
+			ProductCode: product.Code,
+			Amount:      amount + graceInterest - discount,
+			CreatedAt:   createdAt,
+			Status:      commons.StatusLoanNew,
+
+			OriginationFee:  charge.Fee,
+			ProcessingFee:   product.ProcessingFee,
+			DisbursedAmount: charge.Disbursed,
+
+			OriginationFeeTax: charge.Tax,
+
+			PromoCode:      promo.Code,
+			DiscountAmount: discount,
+
+			Currency: product.Currency,
+		}),
+		PayLoans: payLoanEntities,
+	}, nil
+}
+
+// bookLoan save loan draft with its installment schedule, usage of promo is counted when loan use promo
+func (s *Service) bookLoan(ctx context.Context, repo *repository.Repository, draft loanDraft) (entity.LoanEntity, error) {
+	if draft.Loan.PromoCode != "" {
+		used, err := repo.Promo.Use(ctx, draft.Loan.PromoCode)
+		if err != nil {
+			return entity.LoanEntity{}, err
+		}
+
+		if !used {
+			return entity.LoanEntity{}, ErrPromoUsageLimitReached
+		}
+	}
+
+	loan, err := repo.Loan.CreateLoan(ctx, draft.Loan)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	for i := range draft.PayLoans {
+		draft.PayLoans[i].LoanId = loan.Id
+	}
+
+	err = repo.PayLoan.BatchInsert(ctx, draft.PayLoans)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	return loan, nil
+}
+
+// calculateOutstanding return loan amount, fee and tax that not yet paid
+func calculateOutstanding(loan entity.LoanEntity, payLoans []entity.PayLoanEntity) OutstandingEntity {
 	payed := float64(0)
+	fee := float64(0)
+	tax := float64(0)
 	for _, payLoan := range payLoans {
-		if payLoan.Status == commons.StatusPayLoanPayed {
+		switch payLoan.Status {
+		case commons.StatusPayLoanPayed:
 			payed += payLoan.Amount
+		case commons.StatusPayLoanUnpayed:
+			fee += payLoan.FeeAmount
+			tax += payLoan.TaxAmount
 		}
 	}
-	outstanding := loan.Amount - payed
 
-	return outstanding, nil
+	return OutstandingEntity{
+		Currency: loan.Currency,
+		Amount:   loan.Amount - payed,
+		Fee:      fee,
+		Tax:      tax,
+		Total:    loan.Amount - payed + fee + tax,
+	}
 }
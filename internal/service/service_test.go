@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/billing-engine/internal/calendar"
+	"github.com/billing-engine/internal/clock"
 	"github.com/billing-engine/internal/commons"
 	mock_repositories "github.com/billing-engine/internal/mock/repository"
 	"github.com/billing-engine/internal/repository"
@@ -28,6 +30,7 @@ func TestService_IsDelinquent(t *testing.T) {
 			&repository.Repository{
 				User: userRepoMock,
 			},
+			clock.System,
 		)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), username).Return(entity.UserEntity{
@@ -54,6 +57,7 @@ func TestService_IsDelinquent(t *testing.T) {
 			&repository.Repository{
 				User: userRepoMock,
 			},
+			clock.System,
 		)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), username).Return(entity.UserEntity{
@@ -80,6 +84,7 @@ func TestService_IsDelinquent(t *testing.T) {
 			&repository.Repository{
 				User: userRepoMock,
 			},
+			clock.System,
 		)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), username).Return(entity.UserEntity{}, errors.New("any error from repository"))
@@ -89,6 +94,26 @@ func TestService_IsDelinquent(t *testing.T) {
 		assert.NotNil(t, err)
 
 	})
+
+	t.Run("error user not registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(
+			&repository.Repository{
+				User: userRepoMock,
+			},
+			clock.System,
+		)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "unknown").Return(entity.UserEntity{}, nil)
+
+		_, err := service.IsDelinquent(context.Background(), "unknown")
+
+		assert.ErrorIs(t, err, ErrUserNotRegistered)
+	})
 }
 
 func TestService_CreateLoan(t *testing.T) {
@@ -104,18 +129,34 @@ func TestService_CreateLoan(t *testing.T) {
 		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
 		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
 		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
-
-		service := NewService(&repository.Repository{
-			User:    userRepoMock,
-			Loan:    loaRepoMock,
-			PayLoan: payLoanRepoMock,
-		})
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
-			Username: "user123",
-			Status:   commons.StatusUserNew,
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
 		}, nil)
 
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+			Tenor:    50,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
 		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).Return(entity.LoanEntity{
 			Id:        1,
 			Username:  "user123",
@@ -124,7 +165,238 @@ func TestService_CreateLoan(t *testing.T) {
 			CreatedAt: time.Now(),
 		}, nil)
 
-		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).Return(nil)
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 50)
+			assert.Equal(t, 1, payLoans[0].LoanId)
+			return nil
+		})
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+
+		err := service.CreateLoan(context.Background(), data)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("success create loan with accrued grace period", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := CreateLoanEntity{
+			Username:    "user123",
+			Amount:      1000,
+			ProductCode: "grace",
+		}
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserClosedLoan,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "grace").Return(entity.LoanProductEntity{
+			Code:          "grace",
+			Interest:      10,
+			Tenor:         10,
+			GracePeriods:  2,
+			GraceInterest: commons.InterestHandlingAccrued,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		var createdAt time.Time
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			// 1100 after interest plus 2 periods interest of 11
+			assert.Equal(t, "grace", loan.ProductCode)
+			assert.InDelta(t, float64(1122), loan.Amount, 0.001)
+			createdAt = loan.CreatedAt
+			loan.Id = 1
+			return loan, nil
+		})
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 11)
+			assert.Equal(t, createdAt.Add(2*commons.DifferentTime), payLoans[0].CreatedAt)
+			assert.InDelta(t, float64(121), payLoans[0].Amount, 0.001)
+			assert.InDelta(t, float64(22), payLoans[10].Amount, 0.001)
+			assert.Equal(t, payLoans[9].CreatedAt.Add(commons.DifferentTime), payLoans[10].CreatedAt)
+			return nil
+		})
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+
+		err := service.CreateLoan(context.Background(), data)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("success create loan with fees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := CreateLoanEntity{
+			Username:    "user123",
+			Amount:      1000,
+			ProductCode: "fee",
+		}
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "fee").Return(entity.LoanProductEntity{
+			Code:               "fee",
+			Interest:           10,
+			Tenor:              10,
+			OriginationFee:     5,
+			OriginationFeeType: commons.OriginationFeeFinanced,
+			AdminFee:           2,
+			ProcessingFee:      15,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			// origination fee of 50 financed on principal, 1050 after interest
+			assert.InDelta(t, float64(1155), loan.Amount, 0.001)
+			assert.InDelta(t, float64(50), loan.OriginationFee, 0.001)
+			assert.InDelta(t, float64(15), loan.ProcessingFee, 0.001)
+			assert.InDelta(t, float64(1000), loan.DisbursedAmount, 0.001)
+			loan.Id = 1
+			return loan, nil
+		})
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 10)
+			assert.InDelta(t, float64(17), payLoans[0].FeeAmount, 0.001)
+			assert.InDelta(t, float64(2), payLoans[1].FeeAmount, 0.001)
+			assert.InDelta(t, float64(2), payLoans[9].FeeAmount, 0.001)
+			return nil
+		})
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+
+		err := service.CreateLoan(context.Background(), data)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("success create loan with tax on fee and interest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := CreateLoanEntity{
+			Username:    "user123",
+			Amount:      1000,
+			ProductCode: "fee",
+		}
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "fee").Return(entity.LoanProductEntity{
+			Code:               "fee",
+			Interest:           10,
+			Tenor:              10,
+			Country:            "ID",
+			OriginationFee:     5,
+			OriginationFeeType: commons.OriginationFeeDeducted,
+			AdminFee:           2,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), "ID").Return([]entity.TaxRuleEntity{
+			{Country: "ID", Type: commons.TaxTypeVat, Rate: 10},
+			{Country: "ID", Type: commons.TaxTypeWithholding, Rate: 10},
+		}, nil)
+
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			// origination fee 50 and its tax 5 deducted from disbursement
+			assert.InDelta(t, float64(1100), loan.Amount, 0.001)
+			assert.InDelta(t, float64(5), loan.OriginationFeeTax, 0.001)
+			assert.InDelta(t, float64(945), loan.DisbursedAmount, 0.001)
+			loan.Id = 1
+			return loan, nil
+		})
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			assert.Len(t, payLoans, 10)
+			assert.InDelta(t, float64(0.2), payLoans[0].TaxAmount, 0.001)
+			assert.InDelta(t, float64(1.1), payLoans[0].WithholdingTaxAmount, 0.001)
+			assert.InDelta(t, float64(123.2), installmentDue(payLoans[0]), 0.001)
+			return nil
+		})
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
 
 		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
 
@@ -133,7 +405,113 @@ func TestService_CreateLoan(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
-	t.Run("success craete loan new user", func(t *testing.T) {
+	t.Run("success create loan roll due date to business day", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := CreateLoanEntity{
+			Username: "user123",
+			Amount:   1000,
+		}
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		taxRuleRepoMock := mock_repositories.NewMockITaxRuleRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+		holidayRepoMock := mock_repositories.NewMockIHolidayRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			TaxRule:     taxRuleRepoMock,
+			Holiday:     holidayRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			Interest:       10,
+			Tenor:          50,
+			Country:        "ID",
+			RollConvention: commons.RollConventionFollowing,
+		}, nil)
+
+		taxRuleRepoMock.EXPECT().GetByCountry(gomock.Any(), gomock.Any()).Return([]entity.TaxRuleEntity{}, nil)
+
+		holidays := []time.Time{time.Now(), time.Now().AddDate(0, 0, 1)}
+		holidayRepoMock.EXPECT().GetByCountry(gomock.Any(), "ID").Return([]entity.HolidayEntity{
+			{Country: "ID", Date: holidays[0]},
+			{Country: "ID", Date: holidays[1]},
+		}, nil)
+
+		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loan entity.LoanEntity) (entity.LoanEntity, error) {
+			loan.Id = 1
+			return loan, nil
+		})
+
+		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payLoans []entity.PayLoanEntity) error {
+			cal := calendar.NewCalendar("ID", holidays)
+			for _, payLoan := range payLoans {
+				assert.True(t, cal.IsBusinessDay(payLoan.CreatedAt))
+			}
+			return nil
+		})
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+
+		err := service.CreateLoan(context.Background(), data)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error loan product not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := CreateLoanEntity{
+			Username:    "user123",
+			Amount:      1000,
+			ProductCode: "unknown",
+		}
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:        userRepoMock,
+			LoanProduct: loanProductRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycVerified,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "unknown").Return(entity.LoanProductEntity{}, nil)
+
+		err := service.CreateLoan(context.Background(), data)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "loan product not found")
+	})
+
+	t.Run("error user not registered", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -150,30 +528,45 @@ func TestService_CreateLoan(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{}, nil)
 
-		userRepoMock.EXPECT().Create(gomock.Any(), "user123").Return(entity.UserEntity{
+		err := service.CreateLoan(context.Background(), data)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "user not registered")
+	})
+
+	t.Run("error user kyc not verified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		data := CreateLoanEntity{
 			Username: "user123",
-			Status:   commons.StatusUserNew,
-		}, nil)
+			Amount:   50000000,
+		}
 
-		loaRepoMock.EXPECT().CreateLoan(gomock.Any(), gomock.Any()).Return(entity.LoanEntity{
-			Id:        1,
-			Username:  "user123",
-			Amount:    50000000,
-			Status:    0,
-			CreatedAt: time.Now(),
-		}, nil)
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
 
-		payLoanRepoMock.EXPECT().BatchInsert(gomock.Any(), gomock.Any()).Return(nil)
+		service := NewService(&repository.Repository{
+			User:    userRepoMock,
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
 
-		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserActiveLoan).Return(nil)
+		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
+			Username:  "user123",
+			Status:    commons.StatusUserNew,
+			KycStatus: commons.StatusKycPending,
+		}, nil)
 
 		err := service.CreateLoan(context.Background(), data)
 
-		assert.Nil(t, err)
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "user kyc not verified")
 	})
 
 	t.Run("error user already have loan", func(t *testing.T) {
@@ -193,11 +586,12 @@ func TestService_CreateLoan(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), data.Username).Return(entity.UserEntity{
-			Username: "user123",
-			Status:   commons.StatusUserActiveLoan,
+			Username:  "user123",
+			Status:    commons.StatusUserActiveLoan,
+			KycStatus: commons.StatusKycVerified,
 		}, nil)
 
 		err := service.CreateLoan(context.Background(), data)
@@ -220,7 +614,7 @@ func TestService_GetOutstanding(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
 			Username: "user123",
@@ -237,21 +631,24 @@ func TestService_GetOutstanding(t *testing.T) {
 
 		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), gomock.Any()).Return([]entity.PayLoanEntity{
 			{
-				Id:     123,
-				Amount: 550000,
-				Status: commons.StatusPayLoanPayed,
+				Id:        123,
+				Amount:    550000,
+				FeeAmount: 5000,
+				Status:    commons.StatusPayLoanPayed,
 			}, {
-				Id:     124,
-				Amount: 550000,
-				Status: commons.StatusPayLoanUnpayed,
+				Id:        124,
+				Amount:    550000,
+				FeeAmount: 5000,
+				Status:    commons.StatusPayLoanUnpayed,
 			},
 		}, nil)
 
-		amount, err := service.GetOutStanding(context.Background(), "user123")
+		outstanding, err := service.GetOutStanding(context.Background(), "user123")
 
 		assert.Nil(t, err)
-		assert.NotNil(t, amount)
-		assert.Equal(t, amount, float64(54450000))
+		assert.Equal(t, float64(54450000), outstanding.Amount)
+		assert.Equal(t, float64(5000), outstanding.Fee)
+		assert.Equal(t, float64(54455000), outstanding.Total)
 	})
 
 	t.Run("error when user not active loan status", func(t *testing.T) {
@@ -266,17 +663,64 @@ func TestService_GetOutstanding(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
 			Username: "user123",
 			Status:   commons.StatusUserClosedLoan,
 		}, nil)
 
-		amount, err := service.GetOutStanding(context.Background(), "user123")
+		outstanding, err := service.GetOutStanding(context.Background(), "user123")
 
-		assert.NotNil(t, err)
-		assert.Equal(t, amount, float64(0))
+		assert.ErrorIs(t, err, ErrUserNotOnOpenLoan)
+		assert.Equal(t, float64(0), outstanding.Total)
+	})
+
+	t.Run("error when user not registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "unknown").Return(entity.UserEntity{}, nil)
+
+		_, err := service.GetOutStanding(context.Background(), "unknown")
+
+		var serviceErr *Error
+		assert.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, ErrorKindNotFound, serviceErr.Kind)
+		assert.Equal(t, "USER_NOT_REGISTERED", serviceErr.Code)
+	})
+
+	t.Run("error when loan not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User: userRepoMock,
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{}, repository.ErrNotFound)
+
+		_, err := service.GetOutStanding(context.Background(), "user123")
+
+		var serviceErr *Error
+		assert.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, ErrorKindNotFound, serviceErr.Kind)
+		assert.Equal(t, "LOAN_NOT_FOUND", serviceErr.Code)
 	})
 }
 
@@ -293,11 +737,12 @@ func TestService_MakePayment(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		data := MakePaymentEntity{
 			Username: "user123",
 			Amount:   5500000,
+			Currency: "IDR",
 		}
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
@@ -307,6 +752,7 @@ func TestService_MakePayment(t *testing.T) {
 
 		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
 			Id:        123,
+			Currency:  "IDR",
 			Username:  "user123",
 			Amount:    55000000,
 			Status:    commons.StatusLoanNew,
@@ -322,18 +768,32 @@ func TestService_MakePayment(t *testing.T) {
 			},
 		}, nil)
 
-		payLoanRepoMock.EXPECT().Update(gomock.Any(), gomock.Any(), entity.PayLoanEntity{
-			Status: commons.StatusPayLoanPayed,
+		payLoanRepoMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id int, data entity.PayLoanEntity) error {
+			assert.Equal(t, commons.StatusPayLoanPayed, data.Status)
+			assert.False(t, data.PaidAt.IsZero())
+			assert.Equal(t, float64(5500000), data.PaidAmount)
+			return nil
 		})
 
-		message, err := service.MakePayment(context.Background(), data)
+		nextDue := time.Now().AddDate(0, 0, 7)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 122, LoanId: 123, Amount: 5500000, Status: commons.StatusPayLoanPayed},
+			{Id: 123, LoanId: 123, Amount: 5500000, Status: commons.StatusPayLoanPayed},
+			{Id: 125, LoanId: 123, Amount: 5500000, Status: commons.StatusPayLoanUnpayed, CreatedAt: nextDue.AddDate(0, 0, 7)},
+			{Id: 124, LoanId: 123, Amount: 5500000, Status: commons.StatusPayLoanUnpayed, CreatedAt: nextDue},
+		}, nil)
+
+		result, err := service.MakePayment(context.Background(), data)
 
 		assert.Nil(t, err)
-		assert.NotNil(t, message)
-		assert.Equal(t, message, "success make payment")
+		assert.True(t, result.Accepted)
+		assert.Equal(t, "success make payment", result.Message)
+		assert.Equal(t, 2, result.InstallmentsSettled)
+		assert.Equal(t, float64(44000000), result.Outstanding)
+		assert.Equal(t, nextDue, result.NextDueDate)
 	})
 
-	t.Run("error amount not same with requirment", func(t *testing.T) {
+	t.Run("success make payment include installment fee", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -345,11 +805,90 @@ func TestService_MakePayment(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:       123,
+			Currency: "IDR",
+			Username: "user123",
+			Status:   commons.StatusLoanNew,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetInSpecificTimeAndStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.PayLoanEntity{
+			{
+				Id:        123,
+				LoanId:    123,
+				Amount:    5500000,
+				FeeAmount: 5000,
+				Status:    commons.StatusPayLoanUnpayed,
+			},
+		}, nil).Times(2)
+
+		payLoanRepoMock.EXPECT().Update(gomock.Any(), 123, gomock.Any()).DoAndReturn(func(ctx context.Context, id int, data entity.PayLoanEntity) error {
+			assert.Equal(t, commons.StatusPayLoanPayed, data.Status)
+			assert.False(t, data.PaidAt.IsZero())
+			return nil
 		})
 
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
+			Username: "user123",
+			Status:   commons.StatusUserActiveLoan,
+		}, nil)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:       123,
+			Currency: "IDR",
+			Username: "user123",
+			Status:   commons.StatusLoanNew,
+		}, nil)
+
+		result, err := service.MakePayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   5500000,
+			Currency: "IDR",
+		})
+
+		assert.Nil(t, err)
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PaymentReasonAmountMismatch, result.ReasonCode)
+		assert.Equal(t, "amount not same with requirment : 5505000", result.Message)
+
+		result, err = service.MakePayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   5505000,
+			Currency: "IDR",
+		})
+
+		assert.Nil(t, err)
+		assert.True(t, result.Accepted)
+		assert.True(t, result.NextDueDate.IsZero())
+	})
+
+	t.Run("error amount not same with requirment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:    userRepoMock,
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
 		data := MakePaymentEntity{
 			Username: "user123",
 			Amount:   500000,
+			Currency: "IDR",
 		}
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
@@ -359,6 +898,7 @@ func TestService_MakePayment(t *testing.T) {
 
 		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
 			Id:        123,
+			Currency:  "IDR",
 			Username:  "user123",
 			Amount:    55000000,
 			Status:    commons.StatusLoanNew,
@@ -374,11 +914,12 @@ func TestService_MakePayment(t *testing.T) {
 			},
 		}, nil)
 
-		message, err := service.MakePayment(context.Background(), data)
+		result, err := service.MakePayment(context.Background(), data)
 
 		assert.Nil(t, err)
-		assert.NotNil(t, message)
-		assert.Equal(t, message, fmt.Sprintf("amount not same with requirment : %.2f", float64(5500000)))
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PaymentReasonAmountMismatch, result.ReasonCode)
+		assert.Equal(t, "amount not same with requirment : 5500000", result.Message)
 	})
 
 	t.Run("error already pay", func(t *testing.T) {
@@ -393,11 +934,12 @@ func TestService_MakePayment(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		data := MakePaymentEntity{
 			Username: "user123",
 			Amount:   500000,
+			Currency: "IDR",
 		}
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
@@ -406,16 +948,18 @@ func TestService_MakePayment(t *testing.T) {
 		}, nil)
 
 		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
-			Id: 123,
+			Id:       123,
+			Currency: "IDR",
 		}, nil)
 
 		payLoanRepoMock.EXPECT().GetInSpecificTimeAndStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.PayLoanEntity{}, nil)
 
-		message, err := service.MakePayment(context.Background(), data)
+		result, err := service.MakePayment(context.Background(), data)
 
 		assert.Nil(t, err)
-		assert.NotNil(t, message)
-		assert.Equal(t, message, "already payed for this week")
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PaymentReasonAlreadyPaid, result.ReasonCode)
+		assert.Equal(t, "already payed for this week", result.Message)
 	})
 
 	t.Run("error not in active loan", func(t *testing.T) {
@@ -430,11 +974,12 @@ func TestService_MakePayment(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		data := MakePaymentEntity{
 			Username: "user123",
 			Amount:   500000,
+			Currency: "IDR",
 		}
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
@@ -444,11 +989,12 @@ func TestService_MakePayment(t *testing.T) {
 
 		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{}, nil)
 
-		message, err := service.MakePayment(context.Background(), data)
+		result, err := service.MakePayment(context.Background(), data)
 
 		assert.Nil(t, err)
-		assert.NotNil(t, message)
-		assert.Equal(t, message, "not have any active loan")
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PaymentReasonNoActiveLoan, result.ReasonCode)
+		assert.Equal(t, "not have any active loan", result.Message)
 	})
 
 	t.Run("user not have active loan", func(t *testing.T) {
@@ -463,11 +1009,12 @@ func TestService_MakePayment(t *testing.T) {
 			User:    userRepoMock,
 			Loan:    loaRepoMock,
 			PayLoan: payLoanRepoMock,
-		})
+		}, clock.System)
 
 		data := MakePaymentEntity{
 			Username: "user123",
 			Amount:   500000,
+			Currency: "IDR",
 		}
 
 		userRepoMock.EXPECT().GetUser(gomock.Any(), "user123").Return(entity.UserEntity{
@@ -475,11 +1022,12 @@ func TestService_MakePayment(t *testing.T) {
 			Status:   commons.StatusUserClosedLoan,
 		}, nil)
 
-		message, err := service.MakePayment(context.Background(), data)
+		result, err := service.MakePayment(context.Background(), data)
 
 		assert.Nil(t, err)
-		assert.NotNil(t, message)
-		assert.Equal(t, message, "user not active loan")
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PaymentReasonUserNotActiveLoan, result.ReasonCode)
+		assert.Equal(t, "user not active loan", result.Message)
 	})
 }
 
@@ -491,14 +1039,18 @@ func TestService_ScheduleTask(t *testing.T) {
 		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
 		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
 		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		holidayRepoMock := mock_repositories.NewMockIHolidayRepository(ctrl)
 
 		service := NewService(&repository.Repository{
-			User:    userRepoMock,
-			Loan:    loaRepoMock,
-			PayLoan: payLoanRepoMock,
-		})
-
-		loaRepoMock.EXPECT().GetByStatus(gomock.Any(), commons.StatusLoanNew).Return([]entity.LoanEntity{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Holiday:     holidayRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return([]entity.LoanEntity{
 			{
 				Id:        123,
 				Username:  "bambang1",
@@ -507,45 +1059,201 @@ func TestService_ScheduleTask(t *testing.T) {
 				CreatedAt: time.Now(),
 			},
 			{
-				Id:        124,
-				Username:  "bambang2",
-				Amount:    55000000,
-				Status:    commons.StatusLoanNew,
-				CreatedAt: time.Now(),
+				Id:          124,
+				Username:    "bambang2",
+				ProductCode: commons.DefaultProductCode,
+				Amount:      55000000,
+				Status:      commons.StatusLoanNew,
+				CreatedAt:   time.Now(),
+			},
+			{
+				Id:          125,
+				Username:    "bambang3",
+				ProductCode: commons.DefaultProductCode,
+				Amount:      55000000,
+				Status:      commons.StatusLoanNew,
+				CreatedAt:   time.Now(),
 			},
 		}, nil)
 
-		payLoanRepoMock.EXPECT().GetInSpecificTimeAndStatus(gomock.Any(), 123, gomock.Any()).Return([]entity.PayLoanEntity{}, nil)
-
 		loaRepoMock.EXPECT().UpdateStatus(gomock.Any(), 123, commons.StatusLoanClosed).Return(nil)
 
-		userRepoMock.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), commons.StatusUserClosedLoan).Return(nil)
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "bambang1", commons.StatusUserClosedLoan).Return(nil)
 
-		payLoanRepoMock.EXPECT().GetInSpecificTimeAndStatus(gomock.Any(), 124, gomock.Any()).Return([]entity.PayLoanEntity{
-			{
-				Id:     123,
-				LoanId: 124,
-				Amount: 50000,
-				Status: commons.StatusPayLoanUnpayed,
-			},
-			{
-				Id:     124,
-				LoanId: 124,
-				Amount: 50000,
-				Status: commons.StatusPayLoanUnpayed,
-			},
-			{
-				Id:     125,
-				LoanId: 124,
-				Amount: 50000,
-				Status: commons.StatusPayLoanUnpayed,
-			},
+		payLoanRepoMock.EXPECT().GetFullyPaidLoanIds(gomock.Any(), []int{123, 124, 125}).Return([]int{123}, nil)
+
+		payLoanRepoMock.EXPECT().CountOverdueByLoanIds(gomock.Any(), []int{123, 124, 125}, gomock.Any()).Return([]entity.OverdueEntity{
+			{LoanId: 124, OverdueCount: 3},
+			{LoanId: 125, OverdueCount: 2, OldestDueDate: time.Now().Add(-2 * time.Second)},
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:           commons.DefaultProductCode,
+			Country:        "ID",
+			RollConvention: commons.RollConventionFollowing,
+		}, nil).Times(1)
+
+		// product roll due date, so overdue installment is loaded to roll them
+		payLoanRepoMock.EXPECT().GetOverdueByLoanIds(gomock.Any(), []int{124, 125}, gomock.Any()).Return([]entity.PayLoanEntity{
+			{Id: 123, LoanId: 124, Amount: 50000, Status: commons.StatusPayLoanUnpayed},
+			{Id: 124, LoanId: 124, Amount: 50000, Status: commons.StatusPayLoanUnpayed},
+			{Id: 125, LoanId: 124, Amount: 50000, Status: commons.StatusPayLoanUnpayed},
+			{Id: 126, LoanId: 125, Amount: 50000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(-2 * time.Second)},
+			{Id: 127, LoanId: 125, Amount: 50000, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().Add(-time.Second)},
+		}, nil)
+
+		// today is holiday, installment due today not late yet
+		holidayRepoMock.EXPECT().GetByCountry(gomock.Any(), "ID").Return([]entity.HolidayEntity{
+			{Country: "ID", Date: time.Now().AddDate(0, 0, -1)},
+			{Country: "ID", Date: time.Now()},
+		}, nil).Times(1)
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "bambang2", commons.StatusUserDeliquent).Return(nil)
+
+		summary, err := service.ScheduleTask(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, ScheduleTaskSummary{Processed: 3, Closed: 1, Delinquent: 1}, summary)
+	})
+
+	t.Run("scan next page after last loan id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:    userRepoMock,
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		firstPage := []entity.LoanEntity{}
+		for i := 1; i <= scheduleTaskPageSize; i++ {
+			firstPage = append(firstPage, entity.LoanEntity{Id: i, Username: fmt.Sprintf("user%d", i)})
+		}
+
+		loaRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return(firstPage, nil)
+		loaRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, scheduleTaskPageSize, scheduleTaskPageSize).Return([]entity.LoanEntity{
+			{Id: scheduleTaskPageSize + 1, Username: "last"},
 		}, nil)
 
-		userRepoMock.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), commons.StatusUserDeliquent).Return(nil)
+		payLoanRepoMock.EXPECT().GetFullyPaidLoanIds(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, loanIds []int) ([]int, error) {
+			return loanIds, nil
+		}).Times(2)
+		payLoanRepoMock.EXPECT().CountOverdueByLoanIds(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.OverdueEntity{}, nil).Times(2)
+		loaRepoMock.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), commons.StatusLoanClosed).Return(nil).Times(scheduleTaskPageSize + 1)
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), commons.StatusUserClosedLoan).Return(nil).Times(scheduleTaskPageSize + 1)
 
-		err := service.ScheduleTask(context.Background())
+		summary, err := service.ScheduleTask(context.Background())
 
 		assert.Nil(t, err)
+		assert.Equal(t, scheduleTaskPageSize+1, summary.Processed)
+		assert.Equal(t, scheduleTaskPageSize+1, summary.Closed)
+	})
+
+	t.Run("retry failed loan and continue other loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			User:    userRepoMock,
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return([]entity.LoanEntity{
+			{Id: 1, Username: "broken"},
+			{Id: 2, Username: "flaky"},
+			{Id: 3, Username: "fine"},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetFullyPaidLoanIds(gomock.Any(), []int{1, 2, 3}).Return([]int{1, 2, 3}, nil)
+		payLoanRepoMock.EXPECT().CountOverdueByLoanIds(gomock.Any(), []int{1, 2, 3}, gomock.Any()).Return([]entity.OverdueEntity{}, nil)
+
+		loaRepoMock.EXPECT().UpdateStatus(gomock.Any(), 1, commons.StatusLoanClosed).Return(errors.New("database down")).Times(scheduleTaskAttempts)
+
+		gomock.InOrder(
+			loaRepoMock.EXPECT().UpdateStatus(gomock.Any(), 2, commons.StatusLoanClosed).Return(errors.New("deadlock")),
+			loaRepoMock.EXPECT().UpdateStatus(gomock.Any(), 2, commons.StatusLoanClosed).Return(nil),
+		)
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "flaky", commons.StatusUserClosedLoan).Return(nil)
+
+		loaRepoMock.EXPECT().UpdateStatus(gomock.Any(), 3, commons.StatusLoanClosed).Return(nil)
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "fine", commons.StatusUserClosedLoan).Return(nil)
+
+		summary, err := service.ScheduleTask(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, summary.Processed)
+		assert.Equal(t, 2, summary.Closed)
+		assert.Equal(t, 1, summary.Failed)
+		assert.Equal(t, 1, summary.Failures[0].LoanId)
+		assert.EqualError(t, summary.Failures[0].Err, "loan 1 failed after 3 attempt: database down")
+	})
+
+	t.Run("error scan loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return(nil, errors.New("database down"))
+
+		summary, err := service.ScheduleTask(context.Background())
+
+		assert.EqualError(t, err, "database down")
+		assert.Equal(t, 0, summary.Processed)
+	})
+}
+
+func TestValidateLoanAmount(t *testing.T) {
+	product := entity.LoanProductEntity{
+		Code:      "weekly",
+		Currency:  "IDR",
+		MinAmount: 1000000,
+		MaxAmount: 50000000,
+	}
+
+	tests := []struct {
+		name   string
+		amount float64
+		code   string
+	}{
+		{name: "within limit", amount: 5000000},
+		{name: "zero amount", amount: 0, code: "INVALID_AMOUNT"},
+		{name: "below minimum", amount: 500000, code: "AMOUNT_BELOW_MINIMUM"},
+		{name: "above maximum", amount: 60000000, code: "AMOUNT_ABOVE_MAXIMUM"},
+		{name: "precision exceed currency minor unit", amount: 5000000.5, code: "AMOUNT_PRECISION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLoanAmount(product, tt.amount)
+
+			if tt.code == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			var serviceErr *Error
+			assert.True(t, errors.As(err, &serviceErr))
+			assert.Equal(t, tt.code, serviceErr.Code)
+			assert.Equal(t, "amount", serviceErr.Field)
+		})
+	}
+
+	t.Run("no limit when not set on product", func(t *testing.T) {
+		assert.Nil(t, validateLoanAmount(entity.LoanProductEntity{Currency: "USD"}, 0.5))
 	})
 }
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/statement"
+)
+
+// StatementQuery select statement of loan for period From until To (exclusive) in format html or pdf
+type StatementQuery struct {
+	LoanId int
+	From   time.Time
+	To     time.Time
+	Format string
+}
+
+// StatementDocument is rendered statement, Stored is true when it is generated before by monthly batch
+type StatementDocument struct {
+	LoanId  int
+	From    time.Time
+	To      time.Time
+	Format  string
+	Content []byte
+	Stored  bool
+}
+
+var statementFormats = []string{statement.FormatHTML, statement.FormatPDF}
+
+// GetStatement return statement generated by monthly batch when exist, otherwise render it from current data
+func (s *Service) GetStatement(ctx context.Context, query StatementQuery) (StatementDocument, error) {
+	if query.Format != statement.FormatHTML && query.Format != statement.FormatPDF {
+		return StatementDocument{}, ErrInvalidStatementFormat
+	}
+
+	if !query.From.Before(query.To) {
+		return StatementDocument{}, ErrInvalidDateRange
+	}
+
+	document := StatementDocument{
+		LoanId: query.LoanId,
+		From:   query.From,
+		To:     query.To,
+		Format: query.Format,
+	}
+
+	stored, err := s.repo.Statement.Get(ctx, query.LoanId, query.From, query.To.AddDate(0, 0, -1), query.Format)
+	if err == nil {
+		document.Content = stored.Content
+		document.Stored = true
+		return document, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return StatementDocument{}, err
+	}
+
+	loan, err := s.GetLoan(ctx, query.LoanId)
+	if err != nil {
+		return StatementDocument{}, err
+	}
+
+	st, err := s.buildStatement(ctx, loan, query.From, query.To)
+	if err != nil {
+		return StatementDocument{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := statement.Render(&buf, query.Format, st); err != nil {
+		return StatementDocument{}, err
+	}
+	document.Content = buf.Bytes()
+
+	return document, nil
+}
+
+// GenerateMonthlyStatements render and save statement of every open loan for calendar month of month,
+// statement already generated is skipped so it is safe to run again. Open loan is read page by page with keyset on id
+// like schedule task. Return number of statement generated
+func (s *Service) GenerateMonthlyStatements(ctx context.Context, month time.Time) (int, error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+
+	generated := 0
+	afterId := 0
+	for {
+		page, err := s.repo.Loan.GetByStatusAfterId(ctx, commons.StatusLoanNew, afterId, scheduleTaskPageSize)
+		if err != nil {
+			return generated, err
+		}
+
+		for _, loan := range page {
+			count, err := s.generateLoanStatements(ctx, loan, from, to)
+			generated += count
+			if err != nil {
+				return generated, err
+			}
+		}
+
+		if len(page) < scheduleTaskPageSize {
+			return generated, nil
+		}
+		afterId = page[len(page)-1].Id
+	}
+}
+
+// generateLoanStatements save statement of loan for period in every format not yet generated
+func (s *Service) generateLoanStatements(ctx context.Context, loan entity.LoanEntity, from time.Time, to time.Time) (int, error) {
+	if !loan.CreatedAt.Before(to) {
+		return 0, nil
+	}
+
+	missing := []string{}
+	for _, format := range statementFormats {
+		exists, err := s.repo.Statement.Exists(ctx, loan.Id, from, to.AddDate(0, 0, -1), format)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			missing = append(missing, format)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	st, err := s.buildStatement(ctx, loan, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, format := range missing {
+		var buf bytes.Buffer
+		if err := statement.Render(&buf, format, st); err != nil {
+			return generated, err
+		}
+
+		err := s.repo.Statement.Save(ctx, entity.StatementEntity{
+			LoanId:      loan.Id,
+			PeriodStart: from,
+			PeriodEnd:   to.AddDate(0, 0, -1),
+			Format:      format,
+			Content:     buf.Bytes(),
+			CreatedAt:   s.clock.Now(),
+		})
+		if err != nil {
+			return generated, err
+		}
+		generated++
+	}
+
+	return generated, nil
+}
+
+// buildStatement collect installment due and paid in period, balance is amount due of installment not yet paid
+func (s *Service) buildStatement(ctx context.Context, loan entity.LoanEntity, from time.Time, to time.Time) (statement.Statement, error) {
+	user, err := s.repo.User.GetUser(ctx, loan.Username)
+	if err != nil {
+		return statement.Statement{}, err
+	}
+
+	product, err := s.getLoanProduct(ctx, loan.ProductCode)
+	if err != nil {
+		return statement.Statement{}, err
+	}
+
+	payLoans, err := s.repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+	if err != nil {
+		return statement.Statement{}, err
+	}
+
+	sort.Slice(payLoans, func(i, j int) bool {
+		return payLoans[i].CreatedAt.Before(payLoans[j].CreatedAt)
+	})
+
+	st := statement.Statement{
+		GeneratedAt: s.clock.Now(),
+		From:        from,
+		To:          to,
+		Username:    user.Username,
+		FullName:    user.FullName,
+		Address:     user.Address,
+		LoanId:      loan.Id,
+		ProductName: product.Name,
+		Currency:    loan.Currency,
+		Principal:   loanPrincipal(loan, product),
+		Interest:    product.Interest,
+		Tenor:       product.Tenor,
+		BookedAt:    loan.CreatedAt,
+		LoanStatus:  loanStatusLabel(loan.Status),
+	}
+
+	for _, payLoan := range payLoans {
+		if payLoan.Status != commons.StatusPayLoanUnpayed && payLoan.Status != commons.StatusPayLoanPayed && payLoan.Status != commons.StatusPayLoanWrittenOff {
+			continue
+		}
+
+		line := statement.Line{
+			DueDate:    payLoan.CreatedAt,
+			Amount:     payLoan.Amount,
+			Fee:        payLoan.FeeAmount,
+			Tax:        payLoan.TaxAmount,
+			Discount:   payLoan.DiscountAmount,
+			AmountDue:  installmentDue(payLoan),
+			Status:     payLoanStatusLabel(payLoan.Status),
+			PaidAmount: payLoan.PaidAmount,
+		}
+
+		// installment paid before paid time recorded is treated as paid on due date
+		paid := payLoan.Status == commons.StatusPayLoanPayed
+		if paid {
+			line.PaidAt = payLoan.PaidAt
+			if line.PaidAt.IsZero() {
+				line.PaidAt = payLoan.CreatedAt
+			}
+			if line.PaidAmount == 0 {
+				line.PaidAmount = line.AmountDue
+			}
+		}
+
+		if !payLoan.CreatedAt.Before(from) && payLoan.CreatedAt.Before(to) {
+			st.Installments = append(st.Installments, line)
+			st.TotalDue += line.AmountDue
+		}
+
+		if paid && !line.PaidAt.Before(from) && line.PaidAt.Before(to) {
+			st.Payments = append(st.Payments, line)
+			st.TotalPaid += line.PaidAmount
+		}
+
+		if payLoan.Status == commons.StatusPayLoanWrittenOff {
+			continue
+		}
+		if !paid || !line.PaidAt.Before(from) {
+			st.OpeningBalance += line.AmountDue
+		}
+		if !paid || !line.PaidAt.Before(to) {
+			st.ClosingBalance += line.AmountDue
+		}
+	}
+
+	sort.SliceStable(st.Payments, func(i, j int) bool {
+		return st.Payments[i].PaidAt.Before(st.Payments[j].PaidAt)
+	})
+
+	return st, nil
+}
+
+func loanStatusLabel(status int) string {
+	switch status {
+	case commons.StatusLoanNew:
+		return "open"
+	case commons.StatusLoanClosed:
+		return "closed"
+	case commons.StatusLoanChargedOff:
+		return "charged off"
+	case commons.StatusLoanCancelled:
+		return "cancelled"
+	}
+
+	return "unknown"
+}
+
+func payLoanStatusLabel(status int) string {
+	switch status {
+	case commons.StatusPayLoanUnpayed:
+		return "unpaid"
+	case commons.StatusPayLoanPayed:
+		return "paid"
+	case commons.StatusPayLoanWrittenOff:
+		return "written off"
+	}
+
+	return "unknown"
+}
+
+// loanPrincipal return amount borrowed before interest, that is disbursement plus origination fee and its tax.
+// Loan booked before disbursement was recorded take interest of product out of loan amount
+func loanPrincipal(loan entity.LoanEntity, product entity.LoanProductEntity) float64 {
+	if loan.DisbursedAmount > 0 {
+		return currency.Round(loan.Currency, loan.DisbursedAmount+loan.OriginationFee+loan.OriginationFeeTax)
+	}
+
+	return currency.Round(loan.Currency, loan.Amount-interestPortion(loan.Amount, product.Interest))
+}
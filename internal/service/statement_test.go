@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_buildStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+	loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+	payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+	s := &Service{repo: &repository.Repository{
+		User:        userRepoMock,
+		LoanProduct: loanProductRepoMock,
+		PayLoan:     payLoanRepoMock,
+	}, clock: clock.System}
+
+	loan := entity.LoanEntity{Id: 10, Username: "bambang", ProductCode: "default", Amount: 330, DisbursedAmount: 290, OriginationFee: 10, Currency: "IDR", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	userRepoMock.EXPECT().GetUser(gomock.Any(), "bambang").Return(entity.UserEntity{Username: "bambang", FullName: "Bambang"}, nil)
+	loanProductRepoMock.EXPECT().Get(gomock.Any(), "default").Return(entity.LoanProductEntity{Code: "default", Name: "Default", Interest: 10, Tenor: 3}, nil)
+	payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 10).Return([]entity.PayLoanEntity{
+		// paid before period
+		{Id: 1, LoanId: 10, Amount: 110, CreatedAt: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), Status: commons.StatusPayLoanPayed, PaidAt: time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC), PaidAmount: 110},
+		// due and paid in period
+		{Id: 2, LoanId: 10, Amount: 110, FeeAmount: 5, CreatedAt: time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC), Status: commons.StatusPayLoanPayed, PaidAt: time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC), PaidAmount: 115},
+		// due after period
+		{Id: 3, LoanId: 10, Amount: 110, CreatedAt: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), Status: commons.StatusPayLoanUnpayed},
+		// replaced by restructure
+		{Id: 4, LoanId: 10, Amount: 110, CreatedAt: time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC), Status: commons.StatusPayLoanSuperseded},
+	}, nil)
+
+	st, err := s.buildStatement(context.Background(), loan, from, to)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Bambang", st.FullName)
+	assert.Equal(t, "open", st.LoanStatus)
+	assert.Equal(t, float64(300), st.Principal)
+	assert.Len(t, st.Installments, 1)
+	assert.Equal(t, float64(115), st.Installments[0].AmountDue)
+	assert.Len(t, st.Payments, 1)
+	assert.Equal(t, float64(115), st.TotalDue)
+	assert.Equal(t, float64(115), st.TotalPaid)
+	assert.Equal(t, float64(225), st.OpeningBalance)
+	assert.Equal(t, float64(110), st.ClosingBalance)
+}
+
+func TestLoanPrincipal(t *testing.T) {
+	product := entity.LoanProductEntity{Interest: 10}
+
+	tests := []struct {
+		name string
+		loan entity.LoanEntity
+		want float64
+	}{
+		{name: "origination fee deducted", loan: entity.LoanEntity{Currency: "IDR", Amount: 1100000, DisbursedAmount: 989000, OriginationFee: 10000, OriginationFeeTax: 1000}, want: 1000000},
+		{name: "origination fee financed", loan: entity.LoanEntity{Currency: "IDR", Amount: 1112100, DisbursedAmount: 1000000, OriginationFee: 10000, OriginationFeeTax: 1000}, want: 1011000},
+		{name: "booked before disbursement recorded", loan: entity.LoanEntity{Currency: "IDR", Amount: 1100000}, want: 1000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, loanPrincipal(tt.loan, product))
+		})
+	}
+}
+
+func TestService_GetStatement(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success stored statement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		statementRepoMock := mock_repositories.NewMockIStatementRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Statement: statementRepoMock,
+		}, clock.System)
+
+		statementRepoMock.EXPECT().Get(gomock.Any(), 10, from, periodEnd, "pdf").Return(entity.StatementEntity{Content: []byte("%PDF-1.4")}, nil)
+
+		document, err := service.GetStatement(context.Background(), StatementQuery{LoanId: 10, From: from, To: to, Format: "pdf"})
+
+		assert.Nil(t, err)
+		assert.True(t, document.Stored)
+		assert.Equal(t, []byte("%PDF-1.4"), document.Content)
+	})
+
+	t.Run("success render statement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		statementRepoMock := mock_repositories.NewMockIStatementRepository(ctrl)
+		loanRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Statement:   statementRepoMock,
+			Loan:        loanRepoMock,
+			User:        userRepoMock,
+			LoanProduct: loanProductRepoMock,
+			PayLoan:     payLoanRepoMock,
+		}, clock.System)
+
+		statementRepoMock.EXPECT().Get(gomock.Any(), 10, from, periodEnd, "html").Return(entity.StatementEntity{}, repository.ErrNotFound)
+		loanRepoMock.EXPECT().GetById(gomock.Any(), 10).Return(entity.LoanEntity{Id: 10, Username: "bambang", ProductCode: "default", Currency: "IDR"}, nil)
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "bambang").Return(entity.UserEntity{Username: "bambang"}, nil)
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "default").Return(entity.LoanProductEntity{Code: "default", Name: "Default"}, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 10).Return([]entity.PayLoanEntity{}, nil)
+
+		document, err := service.GetStatement(context.Background(), StatementQuery{LoanId: 10, From: from, To: to, Format: "html"})
+
+		assert.Nil(t, err)
+		assert.False(t, document.Stored)
+		assert.True(t, strings.HasPrefix(string(document.Content), "<!DOCTYPE html>"))
+	})
+
+	t.Run("error invalid format", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.GetStatement(context.Background(), StatementQuery{LoanId: 10, From: from, To: to, Format: "docx"})
+
+		assert.Equal(t, ErrInvalidStatementFormat, err)
+	})
+
+	t.Run("error loan not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		statementRepoMock := mock_repositories.NewMockIStatementRepository(ctrl)
+		loanRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Statement: statementRepoMock,
+			Loan:      loanRepoMock,
+		}, clock.System)
+
+		statementRepoMock.EXPECT().Get(gomock.Any(), 10, from, periodEnd, "html").Return(entity.StatementEntity{}, repository.ErrNotFound)
+		loanRepoMock.EXPECT().GetById(gomock.Any(), 10).Return(entity.LoanEntity{}, repository.ErrNotFound)
+
+		_, err := service.GetStatement(context.Background(), StatementQuery{LoanId: 10, From: from, To: to, Format: "html"})
+
+		assert.Equal(t, ErrLoanNotFound, err)
+	})
+}
+
+func TestService_GenerateMonthlyStatements(t *testing.T) {
+	month := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success generate missing statement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		statementRepoMock := mock_repositories.NewMockIStatementRepository(ctrl)
+		loanRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Statement:   statementRepoMock,
+			Loan:        loanRepoMock,
+			User:        userRepoMock,
+			LoanProduct: loanProductRepoMock,
+			PayLoan:     payLoanRepoMock,
+		}, clock.System)
+
+		// first page is full of loan booked after the month, so the next page is read after its last id
+		firstPage := []entity.LoanEntity{}
+		for id := 1; id <= scheduleTaskPageSize; id++ {
+			firstPage = append(firstPage, entity.LoanEntity{Id: id, ProductCode: "default", CreatedAt: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)})
+		}
+
+		gomock.InOrder(
+			loanRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return(firstPage, nil),
+			loanRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, scheduleTaskPageSize, scheduleTaskPageSize).Return([]entity.LoanEntity{
+				{Id: 510, Username: "bambang", ProductCode: "default", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{Id: 511, Username: "joko", ProductCode: "default", CreatedAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+				// booked after the month
+				{Id: 512, Username: "budi", ProductCode: "default", CreatedAt: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+			}, nil),
+		)
+
+		statementRepoMock.EXPECT().Exists(gomock.Any(), 510, from, periodEnd, "html").Return(true, nil)
+		statementRepoMock.EXPECT().Exists(gomock.Any(), 510, from, periodEnd, "pdf").Return(true, nil)
+		statementRepoMock.EXPECT().Exists(gomock.Any(), 511, from, periodEnd, "html").Return(true, nil)
+		statementRepoMock.EXPECT().Exists(gomock.Any(), 511, from, periodEnd, "pdf").Return(false, nil)
+
+		userRepoMock.EXPECT().GetUser(gomock.Any(), "joko").Return(entity.UserEntity{Username: "joko"}, nil)
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), "default").Return(entity.LoanProductEntity{Code: "default"}, nil)
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 511).Return([]entity.PayLoanEntity{}, nil)
+
+		statementRepoMock.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, data entity.StatementEntity) error {
+			assert.Equal(t, 511, data.LoanId)
+			assert.Equal(t, "pdf", data.Format)
+			assert.Equal(t, from, data.PeriodStart)
+			assert.Equal(t, periodEnd, data.PeriodEnd)
+			assert.True(t, strings.HasPrefix(string(data.Content), "%PDF-1.4"))
+			return nil
+		})
+
+		generated, err := service.GenerateMonthlyStatements(context.Background(), month)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, generated)
+	})
+
+	t.Run("error get open loans", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loanRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loanRepoMock,
+		}, clock.System)
+
+		loanRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return(nil, errors.New("error"))
+
+		_, err := service.GenerateMonthlyStatements(context.Background(), month)
+
+		assert.EqualError(t, err, "error")
+	})
+}
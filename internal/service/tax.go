@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/billing-engine/internal/tax"
+)
+
+type TaxSummaryEntity struct {
+	Currency          string
+	From              time.Time
+	To                time.Time
+	InstallmentVat    float64
+	OriginationFeeVat float64
+	TotalVat          float64
+	WithholdingTax    float64
+}
+
+func (s *Service) getTax(ctx context.Context, country string) (*tax.Tax, error) {
+	taxRules, err := s.repo.TaxRule.GetByCountry(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []tax.Rule{}
+	for _, taxRule := range taxRules {
+		rules = append(rules, tax.Rule{
+			Type: taxRule.Type,
+			Rate: taxRule.Rate,
+		})
+	}
+
+	return tax.NewTax(country, rules), nil
+}
+
+// applyTax charge vat on fee and record tax withheld from interest of every installment
+func applyTax(t *tax.Tax, product entity.LoanProductEntity, payLoans []entity.PayLoanEntity) {
+	for i := range payLoans {
+		payLoans[i].TaxAmount = t.Vat(payLoans[i].FeeAmount)
+		payLoans[i].WithholdingTaxAmount = t.Withholding(interestPortion(payLoans[i].Amount, product.Interest))
+	}
+}
+
+// GetTaxSummary sum tax collected from paid installments that due in date range
+// and tax of origination fee from loan booked in date range, grouped by currency so
+// amount of different currency never added together
+func (s *Service) GetTaxSummary(ctx context.Context, from time.Time, to time.Time) ([]TaxSummaryEntity, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidDateRange
+	}
+
+	installmentTaxes, err := s.repo.PayLoan.SumTax(ctx, from, to, commons.StatusPayLoanPayed)
+	if err != nil {
+		return nil, err
+	}
+
+	originationFeeTaxes, err := s.repo.Loan.SumOriginationFeeTax(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := map[string]*TaxSummaryEntity{}
+	summaryOf := func(code string) *TaxSummaryEntity {
+		if _, ok := summaries[code]; !ok {
+			summaries[code] = &TaxSummaryEntity{Currency: code, From: from, To: to}
+		}
+		return summaries[code]
+	}
+
+	for _, installmentTax := range installmentTaxes {
+		summary := summaryOf(installmentTax.Currency)
+		summary.InstallmentVat = installmentTax.TaxAmount
+		summary.WithholdingTax = installmentTax.WithholdingTaxAmount
+	}
+
+	for _, originationFeeTax := range originationFeeTaxes {
+		summaryOf(originationFeeTax.Currency).OriginationFeeVat = originationFeeTax.TaxAmount
+	}
+
+	result := []TaxSummaryEntity{}
+	for _, summary := range summaries {
+		result = append(result, TaxSummaryEntity{
+			Currency:          summary.Currency,
+			From:              from,
+			To:                to,
+			InstallmentVat:    currency.Round(summary.Currency, summary.InstallmentVat),
+			OriginationFeeVat: currency.Round(summary.Currency, summary.OriginationFeeVat),
+			TotalVat:          currency.Round(summary.Currency, summary.InstallmentVat+summary.OriginationFeeVat),
+			WithholdingTax:    currency.Round(summary.Currency, summary.WithholdingTax),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Currency < result[j].Currency
+	})
+
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_GetTaxSummary(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success get tax summary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan:    loaRepoMock,
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		payLoanRepoMock.EXPECT().SumTax(gomock.Any(), from, to, commons.StatusPayLoanPayed).Return([]entity.TaxSummaryEntity{
+			{Currency: "USD", TaxAmount: 1.1, WithholdingTaxAmount: 0.75},
+			{Currency: "IDR", TaxAmount: 110, WithholdingTaxAmount: 75},
+		}, nil)
+
+		loaRepoMock.EXPECT().SumOriginationFeeTax(gomock.Any(), from, to).Return([]entity.TaxSummaryEntity{
+			{Currency: "IDR", TaxAmount: 550},
+			{Currency: "SGD", TaxAmount: 5.5},
+		}, nil)
+
+		summaries, err := service.GetTaxSummary(context.Background(), from, to)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []TaxSummaryEntity{
+			{Currency: "IDR", From: from, To: to, InstallmentVat: 110, OriginationFeeVat: 550, TotalVat: 660, WithholdingTax: 75},
+			{Currency: "SGD", From: from, To: to, OriginationFeeVat: 5.5, TotalVat: 5.5},
+			{Currency: "USD", From: from, To: to, InstallmentVat: 1.1, TotalVat: 1.1, WithholdingTax: 0.75},
+		}, summaries)
+	})
+
+	t.Run("error invalid date range", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.GetTaxSummary(context.Background(), to, from)
+
+		assert.EqualError(t, err, "from must be before to")
+	})
+
+	t.Run("error sum tax", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			PayLoan: payLoanRepoMock,
+		}, clock.System)
+
+		payLoanRepoMock.EXPECT().SumTax(gomock.Any(), from, to, commons.StatusPayLoanPayed).Return(nil, errors.New("error"))
+
+		_, err := service.GetTaxSummary(context.Background(), from, to)
+
+		assert.EqualError(t, err, "error")
+	})
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/billing-engine/internal/currency"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+)
+
+type WriteOffLoanEntity struct {
+	Username string
+	Reason   string
+}
+
+func (s *Service) WriteOffLoan(ctx context.Context, data WriteOffLoanEntity) (entity.LoanEntity, error) {
+	if data.Reason == "" {
+		return entity.LoanEntity{}, ErrWriteOffReasonMissing
+	}
+
+	loan, err := s.getLoan(ctx, data.Username, commons.StatusLoanNew)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	product, err := s.getLoanProduct(ctx, loan.ProductCode)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	return s.writeOff(ctx, loan, product, data.Reason, s.clock.Now())
+}
+
+// writeOff charge off the remaining balance of loan, unpaid installment no longer due.
+// Loan is charged off first and only while still open, so loan written off by other run
+// return ErrLoanNotOpen and every write is rolled back together
+func (s *Service) writeOff(ctx context.Context, loan entity.LoanEntity, product entity.LoanProductEntity, reason string, now time.Time) (entity.LoanEntity, error) {
+	err := s.repo.Transaction.WithTransaction(ctx, func(repo *repository.Repository) error {
+		payLoans, err := repo.PayLoan.GetPayLoanByLoanId(ctx, loan.Id)
+		if err != nil {
+			return err
+		}
+
+		outstanding := float64(0)
+		for _, payLoan := range payLoans {
+			if payLoan.Status == commons.StatusPayLoanUnpayed {
+				outstanding += payLoan.Amount
+			}
+		}
+
+		loan.Status = commons.StatusLoanChargedOff
+		loan.WrittenOffInterest = interestPortion(outstanding, product.Interest)
+		loan.WrittenOffPrincipal = outstanding - loan.WrittenOffInterest
+		loan.WrittenOffAt = now
+		loan.WriteOffReason = reason
+
+		written, err := repo.Loan.WriteOff(ctx, loan.Id, loan)
+		if err != nil {
+			return err
+		}
+
+		if !written {
+			return ErrLoanNotOpen
+		}
+
+		err = repo.PayLoan.UpdateStatusByLoanId(ctx, loan.Id, commons.StatusPayLoanUnpayed, commons.StatusPayLoanWrittenOff)
+		if err != nil {
+			return err
+		}
+
+		return repo.User.UpdateUser(ctx, loan.Username, commons.StatusUserDeliquent)
+	})
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	return loan, nil
+}
+
+// MakeRecoveryPayment accept amount paid for loan that already charged off up to the written off balance not yet recovered
+func (s *Service) MakeRecoveryPayment(ctx context.Context, data MakePaymentEntity) (entity.LoanEntity, error) {
+	if data.Amount <= 0 {
+		return entity.LoanEntity{}, ErrInvalidAmount
+	}
+
+	if data.Currency == "" {
+		return entity.LoanEntity{}, ErrCurrencyRequired
+	}
+
+	loan, err := s.getLoan(ctx, data.Username, commons.StatusLoanChargedOff)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	if !isSameCurrency(loan, data.Currency) {
+		return entity.LoanEntity{}, errCurrencyMismatch(loan.Currency)
+	}
+
+	remaining := currency.Round(loan.Currency, loan.WrittenOffPrincipal+loan.WrittenOffInterest-loan.RecoveredAmount)
+	if currency.Round(loan.Currency, data.Amount) > remaining {
+		return entity.LoanEntity{}, errRecoveryAboveRemaining(loan.Currency, remaining)
+	}
+
+	err = s.repo.Loan.AddRecovery(ctx, loan.Id, data.Amount)
+	if err != nil {
+		return entity.LoanEntity{}, err
+	}
+
+	loan.RecoveredAmount += data.Amount
+
+	return loan, nil
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/billing-engine/internal/clock"
+	"github.com/billing-engine/internal/commons"
+	mock_repositories "github.com/billing-engine/internal/mock/repository"
+	"github.com/billing-engine/internal/repository"
+	"github.com/billing-engine/internal/repository/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_WriteOffLoan(t *testing.T) {
+	t.Run("success write off loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:     commons.DefaultProductCode,
+			Interest: 10,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{
+			{Id: 1, Amount: 1100, Status: commons.StatusPayLoanPayed},
+			{Id: 2, Amount: 1100, Status: commons.StatusPayLoanUnpayed},
+			{Id: 3, Amount: 1100, Status: commons.StatusPayLoanUnpayed},
+		}, nil)
+
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanWrittenOff).Return(nil)
+
+		loaRepoMock.EXPECT().WriteOff(gomock.Any(), 123, gomock.Any()).Return(true, nil)
+
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserDeliquent).Return(nil)
+
+		loan, err := service.WriteOffLoan(context.Background(), WriteOffLoanEntity{
+			Username: "user123",
+			Reason:   "fraud",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, commons.StatusLoanChargedOff, loan.Status)
+		assert.InDelta(t, float64(2000), loan.WrittenOffPrincipal, 0.001)
+		assert.InDelta(t, float64(200), loan.WrittenOffInterest, 0.001)
+		assert.Equal(t, "fraud", loan.WriteOffReason)
+		assert.False(t, loan.WrittenOffAt.IsZero())
+	})
+
+	t.Run("loan written off by other run change nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanNew).Return(entity.LoanEntity{
+			Id:          123,
+			Username:    "user123",
+			ProductCode: commons.DefaultProductCode,
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code: commons.DefaultProductCode,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return([]entity.PayLoanEntity{}, nil)
+
+		// installment and user is not touched when loan already left open status
+		loaRepoMock.EXPECT().WriteOff(gomock.Any(), 123, gomock.Any()).Return(false, nil)
+
+		_, err := service.WriteOffLoan(context.Background(), WriteOffLoanEntity{
+			Username: "user123",
+			Reason:   "fraud",
+		})
+
+		assert.ErrorIs(t, err, ErrLoanNotOpen)
+	})
+
+	t.Run("error reason is required", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.WriteOffLoan(context.Background(), WriteOffLoanEntity{
+			Username: "user123",
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, err.Error(), "write off reason is required")
+	})
+}
+
+func TestService_MakeRecoveryPayment(t *testing.T) {
+	t.Run("success make recovery payment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanChargedOff).Return(entity.LoanEntity{
+			Id:                  123,
+			Currency:            "IDR",
+			Status:              commons.StatusLoanChargedOff,
+			WrittenOffPrincipal: 500,
+			WrittenOffInterest:  100,
+			RecoveredAmount:     100,
+		}, nil)
+
+		loaRepoMock.EXPECT().AddRecovery(gomock.Any(), 123, float64(500)).Return(nil)
+
+		loan, err := service.MakeRecoveryPayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   500,
+			Currency: "IDR",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, float64(600), loan.RecoveredAmount)
+	})
+
+	t.Run("error amount above remaining written off balance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+
+		service := NewService(&repository.Repository{
+			Loan: loaRepoMock,
+		}, clock.System)
+
+		loaRepoMock.EXPECT().Get(gomock.Any(), "user123", commons.StatusLoanChargedOff).Return(entity.LoanEntity{
+			Id:                  123,
+			Currency:            "IDR",
+			Status:              commons.StatusLoanChargedOff,
+			WrittenOffPrincipal: 500,
+			WrittenOffInterest:  100,
+			RecoveredAmount:     100,
+		}, nil)
+
+		_, err := service.MakeRecoveryPayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   501,
+			Currency: "IDR",
+		})
+
+		var serviceErr *Error
+		assert.True(t, errors.As(err, &serviceErr))
+		assert.Equal(t, ErrorKindValidation, serviceErr.Kind)
+		assert.Equal(t, "RECOVERY_ABOVE_REMAINING", serviceErr.Code)
+		assert.Equal(t, "amount", serviceErr.Field)
+		assert.Equal(t, "amount must be at most 500 remaining written off balance", serviceErr.Message)
+	})
+
+	t.Run("error amount not positive", func(t *testing.T) {
+		service := NewService(&repository.Repository{}, clock.System)
+
+		_, err := service.MakeRecoveryPayment(context.Background(), MakePaymentEntity{
+			Username: "user123",
+			Amount:   0,
+			Currency: "IDR",
+		})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestService_ScheduleTask_WriteOff(t *testing.T) {
+	t.Run("auto write off loan past due", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepoMock := mock_repositories.NewMockIUserRepository(ctrl)
+		loaRepoMock := mock_repositories.NewMockILoanRepository(ctrl)
+		payLoanRepoMock := mock_repositories.NewMockIPayLoanRepository(ctrl)
+		loanProductRepoMock := mock_repositories.NewMockILoanProductRepository(ctrl)
+		transactionRepoMock := mock_repositories.NewMockITransactionRepository(ctrl)
+
+		repo := &repository.Repository{
+			User:        userRepoMock,
+			Loan:        loaRepoMock,
+			PayLoan:     payLoanRepoMock,
+			LoanProduct: loanProductRepoMock,
+			Transaction: transactionRepoMock,
+		}
+		service := NewService(repo, clock.System)
+
+		transactionRepoMock.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repo *repository.Repository) error) error {
+			return fn(repo)
+		})
+
+		loaRepoMock.EXPECT().GetByStatusAfterId(gomock.Any(), commons.StatusLoanNew, 0, scheduleTaskPageSize).Return([]entity.LoanEntity{
+			{Id: 123, Username: "user123", ProductCode: commons.DefaultProductCode},
+		}, nil)
+
+		overdue := []entity.PayLoanEntity{
+			{Id: 1, LoanId: 123, Amount: 1100, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().AddDate(0, 0, -91)},
+			{Id: 2, LoanId: 123, Amount: 1100, Status: commons.StatusPayLoanUnpayed, CreatedAt: time.Now().AddDate(0, 0, -84)},
+		}
+		payLoanRepoMock.EXPECT().GetFullyPaidLoanIds(gomock.Any(), []int{123}).Return([]int{}, nil)
+		payLoanRepoMock.EXPECT().CountOverdueByLoanIds(gomock.Any(), []int{123}, gomock.Any()).Return([]entity.OverdueEntity{
+			{LoanId: 123, OverdueCount: 2, OldestDueDate: overdue[0].CreatedAt},
+		}, nil)
+
+		loanProductRepoMock.EXPECT().Get(gomock.Any(), commons.DefaultProductCode).Return(entity.LoanProductEntity{
+			Code:         commons.DefaultProductCode,
+			Interest:     10,
+			WriteOffDays: 90,
+		}, nil)
+
+		payLoanRepoMock.EXPECT().GetPayLoanByLoanId(gomock.Any(), 123).Return(overdue, nil)
+		payLoanRepoMock.EXPECT().UpdateStatusByLoanId(gomock.Any(), 123, commons.StatusPayLoanUnpayed, commons.StatusPayLoanWrittenOff).Return(nil)
+		loaRepoMock.EXPECT().WriteOff(gomock.Any(), 123, gomock.Any()).DoAndReturn(func(ctx context.Context, loanId int, loan entity.LoanEntity) (bool, error) {
+			assert.Equal(t, "auto write off after 90 days past due", loan.WriteOffReason)
+			return true, nil
+		})
+		userRepoMock.EXPECT().UpdateUser(gomock.Any(), "user123", commons.StatusUserDeliquent).Return(nil).Times(1)
+
+		summary, err := service.ScheduleTask(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, summary.WrittenOff)
+	})
+}
@@ -0,0 +1,158 @@
+package statement
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/billing-engine/internal/currency"
+)
+
+// supported statement format
+const (
+	FormatHTML = "html"
+	FormatPDF  = "pdf"
+)
+
+var ErrUnknownFormat = errors.New("unknown statement format")
+
+//go:embed templates
+var templates embed.FS
+
+// Line is one installment of statement, zero PaidAt mean not paid
+type Line struct {
+	DueDate    time.Time
+	Amount     float64
+	Fee        float64
+	Tax        float64
+	Discount   float64
+	AmountDue  float64
+	Status     string
+	PaidAt     time.Time
+	PaidAmount float64
+}
+
+// Statement is account statement of one loan for period From until To (exclusive).
+// Installments is installment due in period and Payments is installment paid in period
+type Statement struct {
+	GeneratedAt time.Time
+	From        time.Time
+	To          time.Time
+
+	Username string
+	FullName string
+	Address  string
+
+	LoanId      int
+	ProductName string
+	Currency    string
+	Principal   float64
+	Interest    float64
+	Tenor       int
+	BookedAt    time.Time
+	LoanStatus  string
+
+	Installments []Line
+	Payments     []Line
+
+	OpeningBalance float64
+	TotalDue       float64
+	TotalPaid      float64
+	ClosingBalance float64
+}
+
+// PeriodEnd return last day of period
+func (st Statement) PeriodEnd() time.Time {
+	return st.To.AddDate(0, 0, -1)
+}
+
+// ContentType return mime type of format
+func ContentType(format string) string {
+	switch format {
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	}
+
+	return "application/octet-stream"
+}
+
+// Render write statement in format, html is rendered from html template
+// and pdf from text template laid out on fixed width font
+func Render(w io.Writer, format string, st Statement) error {
+	switch format {
+	case FormatHTML:
+		return renderHTML(w, st)
+	case FormatPDF:
+		return renderPDF(w, st)
+	}
+
+	return ErrUnknownFormat
+}
+
+func renderHTML(w io.Writer, st Statement) error {
+	tmpl, err := htmltemplate.New("statement.html").Funcs(funcs(st)).ParseFS(templates, "templates/statement.html")
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, st)
+}
+
+func renderPDF(w io.Writer, st Statement) error {
+	tmpl, err := texttemplate.New("statement.txt").Funcs(funcs(st)).ParseFS(templates, "templates/statement.txt")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, st); err != nil {
+		return err
+	}
+
+	return writePDF(w, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"))
+}
+
+func funcs(st Statement) map[string]any {
+	return map[string]any{
+		"money": func(amount float64) string {
+			return currency.Format(st.Currency, amount)
+		},
+		"date": func(t time.Time) string {
+			if t.IsZero() {
+				return "-"
+			}
+			return t.Format("2006-01-02")
+		},
+		"percent": func(rate float64) string {
+			return currency.Format("", rate*100) + "%"
+		},
+		"left": func(width int, text string) string {
+			return pad(text, width, false)
+		},
+		"right": func(width int, text string) string {
+			return pad(text, width, true)
+		},
+	}
+}
+
+// pad fill text with space to width, text longer than width is cut
+func pad(text string, width int, right bool) string {
+	runes := []rune(text)
+	if len(runes) >= width {
+		return string(runes[:width])
+	}
+
+	space := strings.Repeat(" ", width-len(runes))
+	if right {
+		return space + text
+	}
+
+	return text + space
+}
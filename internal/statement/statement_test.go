@@ -0,0 +1,92 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStatement() Statement {
+	return Statement{
+		GeneratedAt:  time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+		From:         time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Username:     "bambang",
+		FullName:     "Bambang <Pamungkas>",
+		LoanId:       10,
+		ProductName:  "Default (weekly)",
+		Currency:     "IDR",
+		Principal:    5000000,
+		Interest:     0.1,
+		Tenor:        50,
+		BookedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		LoanStatus:   "open",
+		Installments: []Line{{DueDate: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), Amount: 110000, AmountDue: 110000, Status: "paid", PaidAt: time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), PaidAmount: 110000}},
+		Payments:     []Line{{DueDate: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), Amount: 110000, Status: "paid", PaidAt: time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), PaidAmount: 110000}},
+
+		OpeningBalance: 5500000,
+		TotalDue:       110000,
+		TotalPaid:      110000,
+		ClosingBalance: 5390000,
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := Render(&buf, FormatHTML, testStatement())
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Period 2024-02-01 to 2024-02-29")
+	assert.Contains(t, buf.String(), "Bambang &lt;Pamungkas&gt; (bambang)")
+	assert.Contains(t, buf.String(), "<tr><td>Closing balance</td><td class=\"amount\">5,390,000</td></tr>")
+	assert.Contains(t, buf.String(), "<td>2024-02-04</td><td>2024-02-05</td><td class=\"amount\">110,000</td>")
+	assert.Contains(t, buf.String(), "10.00%")
+}
+
+func TestRenderPDF(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := Render(&buf, FormatPDF, testStatement())
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(buf.String(), "%%EOF\n"))
+	assert.Contains(t, buf.String(), "(Loan       : 10 Default \\(weekly\\)) '")
+	assert.Contains(t, buf.String(), "(Closing balance                       5,390,000) '")
+	assert.Contains(t, buf.String(), "/Count 1")
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	err := Render(&bytes.Buffer{}, "docx", testStatement())
+
+	assert.Equal(t, ErrUnknownFormat, err)
+}
+
+func TestWritePDFPaging(t *testing.T) {
+	var buf bytes.Buffer
+	lines := make([]string, pdfLinesPerPage+1)
+
+	err := writePDF(&buf, lines)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "/Kids [4 0 R 6 0 R] /Count 2")
+
+	// every object offset in xref point to start of the object
+	content := buf.String()
+	xref := content[strings.Index(content, "xref\n"):]
+	for i, line := range strings.Split(xref, "\n")[3:7] {
+		offset, convErr := strconv.Atoi(line[:10])
+		assert.NoError(t, convErr)
+		assert.True(t, strings.HasPrefix(content[offset:], fmt.Sprintf("%d 0 obj", i+1)))
+	}
+}
+
+func TestEscapePDF(t *testing.T) {
+	assert.Equal(t, "a\\(b\\)\\\\c?", escapePDF("a(b)\\cé"))
+}
@@ -0,0 +1,44 @@
+package tax
+
+import (
+	"github.com/billing-engine/internal/commons"
+)
+
+type Rule struct {
+	Type int
+	Rate float64
+}
+
+// Tax hold tax rates of one country applied on fee and interest
+type Tax struct {
+	Country         string
+	vatRate         float64
+	withholdingRate float64
+}
+
+func NewTax(country string, rules []Rule) *Tax {
+	tax := &Tax{
+		Country: country,
+	}
+
+	for _, rule := range rules {
+		switch rule.Type {
+		case commons.TaxTypeVat:
+			tax.vatRate += rule.Rate
+		case commons.TaxTypeWithholding:
+			tax.withholdingRate += rule.Rate
+		}
+	}
+
+	return tax
+}
+
+// Vat return value added tax of fee
+func (t *Tax) Vat(fee float64) float64 {
+	return fee * t.vatRate / 100
+}
+
+// Withholding return tax withheld from interest
+func (t *Tax) Withholding(interest float64) float64 {
+	return interest * t.withholdingRate / 100
+}
@@ -0,0 +1,28 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/billing-engine/internal/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTax(t *testing.T) {
+	t.Run("rates of same type are summed", func(t *testing.T) {
+		tax := NewTax("ID", []Rule{
+			{Type: commons.TaxTypeVat, Rate: 10},
+			{Type: commons.TaxTypeVat, Rate: 1},
+			{Type: commons.TaxTypeWithholding, Rate: 15},
+		})
+
+		assert.InDelta(t, float64(11), tax.Vat(100), 0.001)
+		assert.InDelta(t, float64(15), tax.Withholding(100), 0.001)
+	})
+
+	t.Run("no rule means no tax", func(t *testing.T) {
+		tax := NewTax("SG", nil)
+
+		assert.Equal(t, float64(0), tax.Vat(100))
+		assert.Equal(t, float64(0), tax.Withholding(100))
+	})
+}
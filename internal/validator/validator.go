@@ -0,0 +1,193 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FieldError describe one field of request that break its rule
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,50}$`)
+
+// Validate check every field of struct against rules in its `validate` tag, example :
+//
+//	Amount float64 `json:"amount" validate:"required,gt=0,max=1000000000,decimals=2"`
+//
+// supported rule are required, username, gt, gte, min, max, len and decimals.
+// Rule other than required is skipped for empty value, so optional field only checked when filled.
+// Every rule of the struct is parsed before any value is checked, so unknown rule, invalid param
+// or rule not fit to kind of field is returned as error even for empty struct
+func Validate(v interface{}) ([]FieldError, error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	fieldRules := make([][]rule, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		rules, err := parseRules(value.Type().Field(i))
+		if err != nil {
+			return nil, err
+		}
+		fieldRules[i] = rules
+	}
+
+	errs := []FieldError{}
+	for i, rules := range fieldRules {
+		name := fieldName(value.Type().Field(i))
+		for _, rule := range rules {
+			if rule.name != "required" && value.Field(i).IsZero() {
+				continue
+			}
+
+			message, ok := check(value.Field(i), rule)
+			if !ok {
+				errs = append(errs, FieldError{
+					Field:   name,
+					Rule:    rule.name,
+					Message: fmt.Sprintf("%s %s", name, message),
+				})
+				break
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// fieldName use json name of field so error point to field as sent by client
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
+
+// rule is one parsed rule of validate tag, param is raw text for message and limit is its number
+type rule struct {
+	name  string
+	param string
+	limit float64
+}
+
+// parseRules parse validate tag of field and check every rule is known, has valid param and fit kind of field
+func parseRules(field reflect.StructField) ([]rule, error) {
+	tag := field.Tag.Get("validate")
+	if tag == "" {
+		return nil, nil
+	}
+
+	kind := field.Type.Kind()
+	rules := []rule{}
+	for _, text := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(text, "=")
+		r := rule{name: name, param: param}
+
+		switch name {
+		case "required":
+		case "username":
+			if kind != reflect.String {
+				return nil, fmt.Errorf("validator: field %s: rule %s need string field, got %s", field.Name, name, kind)
+			}
+		case "gt", "gte", "min", "max", "len", "decimals":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return nil, fmt.Errorf("validator: field %s: invalid param %q of rule %s", field.Name, param, name)
+			}
+			r.limit = limit
+
+			if !fitKind(name, kind) {
+				return nil, fmt.Errorf("validator: field %s: rule %s not fit %s field", field.Name, name, kind)
+			}
+		default:
+			return nil, fmt.Errorf("validator: field %s: unknown rule %q", field.Name, name)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+// fitKind tell whether rule with param can check field of kind, min and max count characters of string
+func fitKind(rule string, kind reflect.Kind) bool {
+	switch rule {
+	case "len":
+		return kind == reflect.String
+	case "min", "max":
+		return kind == reflect.String || isNumber(kind)
+	}
+
+	return isNumber(kind)
+}
+
+func check(value reflect.Value, r rule) (string, bool) {
+	switch r.name {
+	case "required":
+		if value.Kind() == reflect.String {
+			return "is required", strings.TrimSpace(value.String()) != ""
+		}
+		return "is required", !value.IsZero()
+	case "username":
+		return "must be 3 to 50 characters of letter, number, dot, dash or underscore", usernamePattern.MatchString(value.String())
+	case "gt":
+		return "must be greater than " + r.param, number(value) > r.limit
+	case "gte":
+		return "must be greater than or equal " + r.param, number(value) >= r.limit
+	case "min":
+		if value.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", r.param), float64(len(value.String())) >= r.limit
+		}
+		return "must be at least " + r.param, number(value) >= r.limit
+	case "max":
+		if value.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", r.param), float64(len(value.String())) <= r.limit
+		}
+		return "must be at most " + r.param, number(value) <= r.limit
+	case "len":
+		return fmt.Sprintf("must be %s characters", r.param), float64(len(value.String())) == r.limit
+	case "decimals":
+		return fmt.Sprintf("must have at most %s decimal places", r.param), hasDecimals(number(value), int(r.limit))
+	}
+
+	return "", true
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// number read value of number field, kind is already checked by parseRules
+func number(value reflect.Value) float64 {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint())
+	}
+
+	return value.Float()
+}
+
+func hasDecimals(amount float64, decimals int) bool {
+	scaled := amount * math.Pow(10, float64(decimals))
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type loanRequest struct {
+	Username  string  `json:"username" validate:"required,username"`
+	Amount    float64 `json:"amount" validate:"required,gt=0,max=1000000,decimals=2"`
+	Currency  string  `json:"currency" validate:"len=3"`
+	Periods   int     `json:"periods" validate:"gte=1,max=12"`
+	PromoCode string  `json:"promo_code"`
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid request", func(t *testing.T) {
+		errs, err := Validate(&loanRequest{
+			Username: "bambang",
+			Amount:   1000.5,
+			Currency: "IDR",
+			Periods:  3,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("optional field is skipped when empty", func(t *testing.T) {
+		errs, err := Validate(loanRequest{
+			Username: "bambang",
+			Amount:   1000,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("required field", func(t *testing.T) {
+		errs, err := Validate(loanRequest{Username: "  "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldError{
+			{Field: "username", Rule: "required", Message: "username is required"},
+			{Field: "amount", Rule: "required", Message: "amount is required"},
+		}, errs)
+	})
+
+	t.Run("report first broken rule of every field", func(t *testing.T) {
+		errs, err := Validate(loanRequest{
+			Username: "bam bang",
+			Amount:   -10.123,
+			Currency: "RUPIAH",
+			Periods:  24,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldError{
+			{Field: "username", Rule: "username", Message: "username must be 3 to 50 characters of letter, number, dot, dash or underscore"},
+			{Field: "amount", Rule: "gt", Message: "amount must be greater than 0"},
+			{Field: "currency", Rule: "len", Message: "currency must be 3 characters"},
+			{Field: "periods", Rule: "max", Message: "periods must be at most 12"},
+		}, errs)
+	})
+
+	t.Run("decimal precision", func(t *testing.T) {
+		errs, err := Validate(loanRequest{
+			Username: "bambang",
+			Amount:   1000.125,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []FieldError{
+			{Field: "amount", Rule: "decimals", Message: "amount must have at most 2 decimal places"},
+		}, errs)
+
+		errs, err = Validate(loanRequest{Username: "bambang", Amount: 0.29})
+		assert.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+	t.Run("broken rule return error", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input interface{}
+			err   string
+		}{
+			{
+				name: "unknown rule",
+				input: struct {
+					Name string `validate:"required,email"`
+				}{},
+				err: `validator: field Name: unknown rule "email"`,
+			},
+			{
+				name: "invalid param",
+				input: struct {
+					Amount float64 `validate:"max=ten"`
+				}{},
+				err: `validator: field Amount: invalid param "ten" of rule max`,
+			},
+			{
+				name: "number rule on string field",
+				input: struct {
+					Code string `validate:"gt=0"`
+				}{Code: "A"},
+				err: "validator: field Code: rule gt not fit string field",
+			},
+			{
+				name: "len on number field",
+				input: struct {
+					Tenor int `validate:"len=3"`
+				}{},
+				err: "validator: field Tenor: rule len not fit int field",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				errs, err := Validate(tt.input)
+
+				assert.EqualError(t, err, tt.err)
+				assert.Nil(t, errs)
+			})
+		}
+	})
+}
@@ -1,11 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
-	"time"
 
 	"github.com/billing-engine/boostrap"
-	"github.com/billing-engine/internal/commons"
 	"github.com/billing-engine/internal/controller"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -25,19 +24,49 @@ func main() {
 	v1.Get("/is-delinquent", controller.IsDelinquent)     // ✅
 	v1.Post("/make-payment", controller.MakePayment)      // ✅
 	v1.Post("/create-loan", controller.CreateLoan)        // ✅
+	v1.Post("/create-borrower", controller.CreateBorrower)
+	v1.Post("/update-borrower", controller.UpdateBorrower)
+	v1.Post("/review-kyc", controller.ReviewKyc) // back office only, never exposed to borrower
+	v1.Get("/get-borrower", controller.GetBorrower)
+	v1.Post("/restructure-loan", controller.RestructureLoan)
+	v1.Post("/payment-holiday", controller.GrantPaymentHoliday)
+	v1.Post("/write-off-loan", controller.WriteOffLoan)
+	v1.Post("/make-recovery-payment", controller.MakeRecoveryPayment)
+	v1.Post("/cancel-loan", controller.CancelLoan)
+	v1.Post("/refinance-loan", controller.RefinanceLoan)
+	v1.Get("/tax-summary", controller.GetTaxSummary)
+	v1.Post("/quote-loan", controller.QuoteLoan)
+	v1.Get("/portfolio", controller.GetPortfolio)
 
-	// schedule apps for checking loan from borrower
-	go func() {
-		ticker := time.NewTicker(time.Duration(commons.DifferentTime))
-		defer ticker.Stop()
-
-		for range ticker.C {
-			err := controller.ProcessScheduleTask()
-			if err != nil {
-				log.Println("some error from scheduler", err)
-			}
-		}
-	}()
+	// resource oriented read route, v1 read route above is kept for old client
+	v2 := api.Group("/v2")
+	v2.Get("/borrowers/:username", controller.GetBorrower)
+	v2.Get("/borrowers/:username/outstanding", controller.GetOutstanding)
+	v2.Get("/borrowers/:username/delinquency", controller.IsDelinquent)
+	v2.Get("/borrowers/:username/schedule", controller.GetBorrowerSchedule)
+	v2.Get("/borrowers/:username/loans", controller.ListLoans)
+	v2.Get("/borrowers/:username/payments", controller.ListPayments)
+	v2.Get("/loans/:id", controller.GetLoan)
+	v2.Get("/loans/:id/schedule", controller.GetLoanSchedule)
+	v2.Get("/loans/:id/statement", controller.GetStatement)
+	v2.Get("/tax-summary", controller.GetTaxSummary)
+	v2.Get("/portfolio", controller.GetPortfolio)
+	v2.Get("/reports/portfolio", controller.GetPortfolioReport)
+	v2.Get("/exports/:dataset", controller.Export)
+	v2.Get("/jobs/:name/runs", controller.GetJobRuns)
+
+	// time travel for QA, only served when app run on virtual clock
+	if appConfig.Simulation != nil {
+		v2.Get("/simulation/clock", controller.GetClock)
+		v2.Post("/simulation/advance", controller.AdvanceClock)
+	}
+
+	// scheduler run job on cron expression from config, every run recorded to job_runs
+	// and run missed while app down is caught up at start
+	if err := controller.RegisterJobs(appConfig.Scheduler, appConfig.Config.Scheduler.Jobs); err != nil {
+		log.Fatal("error register scheduler job ", err)
+	}
+	go appConfig.Scheduler.Start(context.Background())
 
 	log.Fatal(app.Listen(":9005"))
 }
@@ -0,0 +1,10 @@
+ALTER TABLE users DROP INDEX idx_users_national_id;
+ALTER TABLE users DROP COLUMN kyc_verified_at;
+ALTER TABLE users DROP COLUMN kyc_submitted_at;
+ALTER TABLE users DROP COLUMN kyc_status;
+ALTER TABLE users DROP COLUMN date_of_birth;
+ALTER TABLE users DROP COLUMN address;
+ALTER TABLE users DROP COLUMN email;
+ALTER TABLE users DROP COLUMN phone;
+ALTER TABLE users DROP COLUMN national_id;
+ALTER TABLE users DROP COLUMN full_name;
@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN full_name varchar(255) DEFAULT NULL;
+ALTER TABLE users ADD COLUMN national_id varchar(32) DEFAULT NULL;
+ALTER TABLE users ADD COLUMN phone varchar(32) DEFAULT NULL;
+ALTER TABLE users ADD COLUMN email varchar(255) DEFAULT NULL;
+ALTER TABLE users ADD COLUMN address text DEFAULT NULL;
+ALTER TABLE users ADD COLUMN date_of_birth DATE DEFAULT NULL;
+ALTER TABLE users ADD COLUMN kyc_status int(2) NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN kyc_submitted_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE users ADD COLUMN kyc_verified_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE users ADD UNIQUE INDEX idx_users_national_id (national_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS loan_restructure (
+    id int(11) PRIMARY KEY AUTO_INCREMENT,
+    loan_id int(11) NOT NULL,
+    outstanding DECIMAL(15, 2) NOT NULL,
+    arrears DECIMAL(15, 2) NOT NULL,
+    tenor int(11) NOT NULL,
+    holiday_periods int(11) NOT NULL DEFAULT 0,
+    capitalize_arrears tinyint(1) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    INDEX idx_loan_restructure_loan_id (loan_id)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS loan_product (
+    code varchar(64) PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    interest DECIMAL(5, 2) NOT NULL,
+    tenor int(11) NOT NULL,
+    grace_periods int(11) NOT NULL DEFAULT 0,
+    grace_interest int(2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO loan_product (code, name, interest, tenor, grace_periods, grace_interest) VALUES ('default', 'Weekly Loan 50 Weeks', 10, 50, 0, 0);
+
+ALTER TABLE loan ADD COLUMN product_code varchar(64) NOT NULL DEFAULT 'default';
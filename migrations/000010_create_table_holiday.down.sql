@@ -0,0 +1,3 @@
+ALTER TABLE loan_product DROP COLUMN roll_convention;
+ALTER TABLE loan_product DROP COLUMN country;
+DROP TABLE IF EXISTS holiday;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS holiday (
+    id int(11) PRIMARY KEY AUTO_INCREMENT,
+    country varchar(2) NOT NULL,
+    date DATE NOT NULL,
+    name varchar(255) NOT NULL,
+    UNIQUE INDEX idx_holiday_country_date (country, date)
+);
+
+ALTER TABLE loan_product ADD COLUMN country varchar(2) NOT NULL DEFAULT 'ID';
+ALTER TABLE loan_product ADD COLUMN roll_convention int(2) NOT NULL DEFAULT 0;
+
+UPDATE loan_product SET roll_convention = 2 WHERE code = 'default';
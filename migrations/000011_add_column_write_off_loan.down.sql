@@ -0,0 +1,7 @@
+ALTER TABLE loan_product DROP COLUMN write_off_days;
+
+ALTER TABLE loan DROP COLUMN recovered_amount;
+ALTER TABLE loan DROP COLUMN write_off_reason;
+ALTER TABLE loan DROP COLUMN written_off_at;
+ALTER TABLE loan DROP COLUMN written_off_interest;
+ALTER TABLE loan DROP COLUMN written_off_principal;
@@ -0,0 +1,9 @@
+ALTER TABLE loan ADD COLUMN written_off_principal DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE loan ADD COLUMN written_off_interest DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE loan ADD COLUMN written_off_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE loan ADD COLUMN write_off_reason varchar(255) DEFAULT NULL;
+ALTER TABLE loan ADD COLUMN recovered_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE loan_product ADD COLUMN write_off_days int(11) NOT NULL DEFAULT 0;
+
+UPDATE loan_product SET write_off_days = 90 WHERE code = 'default';
@@ -0,0 +1,4 @@
+ALTER TABLE loan_product DROP COLUMN cooling_off_days;
+
+ALTER TABLE loan DROP COLUMN closed_reason;
+ALTER TABLE loan DROP COLUMN closed_at;
@@ -0,0 +1,6 @@
+ALTER TABLE loan ADD COLUMN closed_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE loan ADD COLUMN closed_reason varchar(255) DEFAULT NULL;
+
+ALTER TABLE loan_product ADD COLUMN cooling_off_days int(11) NOT NULL DEFAULT 0;
+
+UPDATE loan_product SET cooling_off_days = 14 WHERE code = 'default';
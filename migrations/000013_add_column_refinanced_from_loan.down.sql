@@ -0,0 +1 @@
+ALTER TABLE loan DROP COLUMN refinanced_from;
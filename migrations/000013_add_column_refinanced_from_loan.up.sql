@@ -0,0 +1 @@
+ALTER TABLE loan ADD COLUMN refinanced_from int(11) NOT NULL DEFAULT 0;
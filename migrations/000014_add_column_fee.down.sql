@@ -0,0 +1,10 @@
+ALTER TABLE pay_loan DROP COLUMN fee_amount;
+
+ALTER TABLE loan DROP COLUMN disbursed_amount;
+ALTER TABLE loan DROP COLUMN processing_fee;
+ALTER TABLE loan DROP COLUMN origination_fee;
+
+ALTER TABLE loan_product DROP COLUMN processing_fee;
+ALTER TABLE loan_product DROP COLUMN admin_fee;
+ALTER TABLE loan_product DROP COLUMN origination_fee_type;
+ALTER TABLE loan_product DROP COLUMN origination_fee;
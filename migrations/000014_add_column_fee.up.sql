@@ -0,0 +1,10 @@
+ALTER TABLE loan_product ADD COLUMN origination_fee DECIMAL(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE loan_product ADD COLUMN origination_fee_type int(11) NOT NULL DEFAULT 0;
+ALTER TABLE loan_product ADD COLUMN admin_fee DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE loan_product ADD COLUMN processing_fee DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE loan ADD COLUMN origination_fee DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE loan ADD COLUMN processing_fee DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE loan ADD COLUMN disbursed_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE pay_loan ADD COLUMN fee_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
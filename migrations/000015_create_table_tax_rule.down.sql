@@ -0,0 +1,6 @@
+ALTER TABLE pay_loan DROP COLUMN withholding_tax_amount;
+ALTER TABLE pay_loan DROP COLUMN tax_amount;
+
+ALTER TABLE loan DROP COLUMN origination_fee_tax;
+
+DROP TABLE IF EXISTS tax_rule;
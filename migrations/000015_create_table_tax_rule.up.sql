@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tax_rule (
+    id int(11) PRIMARY KEY AUTO_INCREMENT,
+    country varchar(2) NOT NULL,
+    type int(2) NOT NULL,
+    rate DECIMAL(5, 2) NOT NULL,
+    name varchar(255) NOT NULL,
+    INDEX idx_tax_rule_country (country)
+);
+
+INSERT INTO tax_rule (country, type, rate, name) VALUES ('ID', 1, 11, 'PPN');
+
+ALTER TABLE loan ADD COLUMN origination_fee_tax DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE pay_loan ADD COLUMN tax_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE pay_loan ADD COLUMN withholding_tax_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
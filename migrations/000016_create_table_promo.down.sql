@@ -0,0 +1,6 @@
+ALTER TABLE pay_loan DROP COLUMN discount_amount;
+
+ALTER TABLE loan DROP COLUMN discount_amount;
+ALTER TABLE loan DROP COLUMN promo_code;
+
+DROP TABLE IF EXISTS promo;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS promo (
+    code varchar(64) PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    discount_type int(2) NOT NULL,
+    discount_value DECIMAL(5, 2) NOT NULL,
+    valid_from TIMESTAMP NULL DEFAULT NULL,
+    valid_to TIMESTAMP NULL DEFAULT NULL,
+    usage_limit int(11) NOT NULL DEFAULT 0,
+    usage_count int(11) NOT NULL DEFAULT 0,
+    product_code varchar(64) NOT NULL DEFAULT '',
+    min_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    max_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    new_borrower_only tinyint(1) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+ALTER TABLE loan ADD COLUMN promo_code varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE loan ADD COLUMN discount_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE pay_loan ADD COLUMN discount_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE loan DROP COLUMN currency;
+
+ALTER TABLE loan_product DROP COLUMN currency;
@@ -0,0 +1,3 @@
+ALTER TABLE loan_product ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE loan ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'IDR';
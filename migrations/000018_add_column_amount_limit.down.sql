@@ -0,0 +1,3 @@
+ALTER TABLE loan_product DROP COLUMN max_amount;
+
+ALTER TABLE loan_product DROP COLUMN min_amount;
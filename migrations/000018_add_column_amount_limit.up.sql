@@ -0,0 +1,3 @@
+ALTER TABLE loan_product ADD COLUMN min_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE loan_product ADD COLUMN max_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
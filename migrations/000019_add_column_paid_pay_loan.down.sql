@@ -0,0 +1,3 @@
+ALTER TABLE pay_loan DROP COLUMN paid_amount;
+
+ALTER TABLE pay_loan DROP COLUMN paid_at;
@@ -0,0 +1,3 @@
+ALTER TABLE pay_loan ADD COLUMN paid_at DATETIME NULL;
+
+ALTER TABLE pay_loan ADD COLUMN paid_amount DECIMAL(15, 2) NOT NULL DEFAULT 0;
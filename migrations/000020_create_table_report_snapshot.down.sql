@@ -0,0 +1,3 @@
+DROP INDEX idx_pay_loan_paid_at ON pay_loan;
+
+DROP TABLE IF EXISTS report_snapshot;
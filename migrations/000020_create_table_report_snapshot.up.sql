@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS report_snapshot (
+    report_date DATE NOT NULL,
+    currency varchar(3) NOT NULL,
+    loan_count int(11) NOT NULL DEFAULT 0,
+    outstanding DECIMAL(20, 2) NOT NULL DEFAULT 0,
+    par30_amount DECIMAL(20, 2) NOT NULL DEFAULT 0,
+    par90_amount DECIMAL(20, 2) NOT NULL DEFAULT 0,
+    par30 DECIMAL(7, 6) NOT NULL DEFAULT 0,
+    par90 DECIMAL(7, 6) NOT NULL DEFAULT 0,
+    delinquent_borrowers int(11) NOT NULL DEFAULT 0,
+    collection_count int(11) NOT NULL DEFAULT 0,
+    collections DECIMAL(20, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (report_date, currency)
+);
+
+CREATE INDEX idx_pay_loan_paid_at ON pay_loan (paid_at);
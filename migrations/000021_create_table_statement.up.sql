@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS statement (
+    id int(11) PRIMARY KEY AUTO_INCREMENT,
+    loan_id int(11) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    format varchar(4) NOT NULL,
+    content MEDIUMBLOB NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE INDEX idx_statement_loan_period_format (loan_id, period_start, period_end, format)
+);
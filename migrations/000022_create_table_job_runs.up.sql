@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+    id int(11) PRIMARY KEY AUTO_INCREMENT,
+    job_name varchar(64) NOT NULL,
+    scheduled_at DATETIME NOT NULL,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME NULL,
+    status int(2) NOT NULL DEFAULT 0,
+    error TEXT NULL,
+    UNIQUE INDEX idx_job_runs_job_scheduled (job_name, scheduled_at)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS locks (
+    name varchar(64) PRIMARY KEY,
+    owner varchar(128) NOT NULL,
+    expires_at DATETIME NOT NULL
+);